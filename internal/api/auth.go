@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -11,12 +12,61 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+const (
+	sessionRefreshWindow = 1 * time.Minute // How long before the JWT expires that the session is refreshed
+)
+
 type authData struct {
 	CSRFToken string
+	ExpiresAt time.Time
 	JWT       string
 	User      User
 }
 
+// expiresSoon returns whether or not the session is expired or about to expire.
+func (a authData) expiresSoon() bool {
+	if a.ExpiresAt.IsZero() {
+		return false
+	}
+	return time.Now().Add(sessionRefreshWindow).After(a.ExpiresAt)
+}
+
+// authenticatedRequestKey is the context key used to flag requests which require an authenticated session.
+type authenticatedRequestKey struct{}
+
+// authenticatedRequest tracks the session used by a single authenticated request across retries.
+type authenticatedRequest struct {
+	jwt      string // JWT the request was last sent with
+	rejected bool   // whether or not the UDM rejected the session the request was last sent with
+	retried  bool   // whether or not the request was already retried after being rejected
+}
+
+// Credentials holds the information needed to authenticate with the UDM.
+type Credentials struct {
+	// Username of the UDM account.
+	Username string
+
+	// Password of the UDM account.
+	Password string
+
+	// MFAToken is a one-time multi-factor authentication code for accounts with MFA enabled.
+	//
+	// Since the code can only be used once, the session cannot be refreshed when it expires unless TOTPSecret is set.
+	MFAToken string
+
+	// TOTPSecret is the base32-encoded secret used to generate multi-factor authentication codes for accounts with MFA
+	// enabled.  It takes precedence over MFAToken.
+	TOTPSecret string
+}
+
+// mfaCode returns the multi-factor authentication code to send with a login request, if any.
+func (c Credentials) mfaCode() (string, error) {
+	if c.TOTPSecret != "" {
+		return GenerateTOTP(c.TOTPSecret, time.Now())
+	}
+	return c.MFAToken, nil
+}
+
 type jwtClaims struct {
 	jwt.RegisteredClaims
 
@@ -33,19 +83,52 @@ type loginRequest struct {
 	Username   string `json:"username"`
 }
 
-type loginResponseError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-	Level   string `json:"level"`
-}
-
 type loginResponseSuccess struct {
 	User
 }
 
-func (c *Client) Login(ctx context.Context, username, password string) error {
+func (c *Client) Login(ctx context.Context, creds Credentials) error {
+	c.loginLock.Lock()
+	c.credentials = creds
+	err := c.authenticate(ctx)
+	c.loginLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// collect application info
+	netInfo, err := c.GetNetworkAppInfo(ctx)
+	if err != nil {
+		return err
+	}
+	c.networkAppInfo = netInfo
+	return nil
+}
+
+// UseAPIKey authenticates every request with the given UniFi OS API key instead of a user session.
+//
+// API keys are created in the UniFi OS console and are sent in the X-API-KEY header, so no login request is made and
+// there is no session to refresh.  The key is verified by collecting the network application info.
+func (c *Client) UseAPIKey(ctx context.Context, apiKey string) error {
+	c.loginLock.Lock()
+	c.apiKey = apiKey
+	c.loginLock.Unlock()
+
+	// collect application info
+	netInfo, err := c.GetNetworkAppInfo(ctx)
+	if err != nil {
+		return err
+	}
+	c.networkAppInfo = netInfo
+	return nil
+}
+
+// authenticate logs into the UDM using the saved credentials and saves the resulting session.
+//
+// The caller must hold c.loginLock.
+func (c *Client) authenticate(ctx context.Context) error {
 	ctx = c.addClientContext(ctx)
-	ctx = tflog.SetField(ctx, "username", username)
+	ctx = tflog.SetField(ctx, "username", c.credentials.Username)
 
 	// POST /api/auth/login
 	url, req := c.newRequest(ctx, "/api/auth/login")
@@ -53,15 +136,23 @@ func (c *Client) Login(ctx context.Context, username, password string) error {
 		"url": url,
 	})
 	apiResponseSuccess := loginResponseSuccess{}
-	apiResponseError := loginResponseError{}
+	apiResponseError := errorResponse{}
 	attempt := 1
-	var err error
 	var resp *resty.Response
 	for {
+		// a new MFA code is generated for every attempt in case the previous one has expired
+		mfaCode, err := c.credentials.mfaCode()
+		if err != nil {
+			tflog.Error(ctx, "failed to generate MFA code", map[string]any{
+				"error_message": err.Error(),
+			})
+			return err
+		}
 		resp, err = req.
 			SetBody(loginRequest{
-				Username: username,
-				Password: password,
+				Username: c.credentials.Username,
+				Password: c.credentials.Password,
+				Token:    mfaCode,
 			}).
 			SetResult(&apiResponseSuccess).
 			SetError(&apiResponseError).
@@ -81,50 +172,142 @@ func (c *Client) Login(ctx context.Context, username, password string) error {
 			break
 		}
 		if apiResponseError.Code == "AUTHENTICATION_FAILED_LIMIT_REACHED" && attempt < 30 {
+			attempt++
 			time.Sleep(1 * time.Second)
 			continue
 		}
-		tflog.Error(ctx, "failed to authenticate user with UDM server", map[string]any{
-			"status_code":   statusCode,
-			"error_code":    apiResponseError.Code,
-			"error_message": apiResponseError.Message,
-		})
-		return fmt.Errorf("authentication failed: %s", apiResponseError.Message)
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to authenticate user with UDM server", apiErr.logFields())
+		return fmt.Errorf("authentication failed: %w", apiErr)
 	}
 	tflog.Debug(ctx, "authentication successful")
 
 	// save the user's information and save the JWT token cookie
-	c.authData.User = apiResponseSuccess.User
+	session := authData{
+		User: apiResponseSuccess.User,
+	}
 	cookies := resp.Cookies()
 	for _, cookie := range cookies {
 		if cookie.Name == JWTCookieName {
-			c.authData.JWT = cookie.Value
+			session.JWT = cookie.Value
 			tflog.Debug(ctx, "located JWT cookie")
 
-			// parse CSRF token from JWT
+			// parse CSRF token and expiration time from JWT
 			var claims jwtClaims
-			_, err := jwt.ParseWithClaims(c.authData.JWT, &claims, nil)
+			_, err := jwt.ParseWithClaims(session.JWT, &claims, nil)
 			if err != nil && !errors.Is(err, jwt.ErrTokenUnverifiable) {
 				tflog.Error(ctx, "failed to parse JWT", map[string]any{
 					"error_message": err.Error(),
 				})
 				return err
 			}
-			c.authData.CSRFToken = claims.CsrfToken
-			tflog.Debug(ctx, "extracted CSRF token from JWT")
+			session.CSRFToken = claims.CsrfToken
+			if claims.ExpiresAt != nil {
+				session.ExpiresAt = claims.ExpiresAt.Time
+			}
+			tflog.Debug(ctx, "extracted CSRF token from JWT", map[string]any{
+				"expires_at": session.ExpiresAt.Format(time.RFC3339),
+			})
 			break
 		}
 	}
-	if c.authData.JWT == "" {
+	if session.JWT == "" {
 		tflog.Error(ctx, "failed to locate JWT cookie")
 		return errors.New("no JWT was returned by the server")
 	}
 
-	// collect application info
-	netInfo, err := c.GetNetworkAppInfo(ctx)
-	if err != nil {
+	c.authLock.Lock()
+	c.authData = session
+	c.authLock.Unlock()
+	return nil
+}
+
+// applySession is a request middleware which adds the API key or the current session to authenticated requests.
+//
+// If the session is about to expire or the UDM rejected the session on a previous attempt of the request, the client
+// logs in again before the request is sent.
+func (c *Client) applySession(_ *resty.Client, req *resty.Request) error {
+	state, ok := req.Context().Value(authenticatedRequestKey{}).(*authenticatedRequest)
+	if !ok {
+		return nil
+	}
+	if c.apiKey != "" {
+		req.SetHeader(APIKeyHeaderName, c.apiKey)
+		return nil
+	}
+	if err := c.refreshSession(req.Context(), state); err != nil {
 		return err
 	}
-	c.networkAppInfo = netInfo
+
+	session := c.session()
+	state.jwt = session.JWT
+	state.rejected = false
+	req.SetHeader("X-Csrf-Token", session.CSRFToken)
+
+	// resty adds cookies to the request's own headers, so the cookie sent with a previous attempt must be removed
+	req.Header.Del("Cookie")
+	req.Cookies = []*http.Cookie{
+		{
+			Name:     JWTCookieName,
+			Value:    session.JWT,
+			Path:     "/",
+			Domain:   c.hostname,
+			Secure:   true,
+			HttpOnly: true,
+			SameSite: http.SameSiteNoneMode,
+		},
+	}
 	return nil
 }
+
+// refreshSession logs in again if the current session is about to expire or was rejected for the given request.
+func (c *Client) refreshSession(ctx context.Context, state *authenticatedRequest) error {
+	needsRefresh := func() bool {
+		session := c.session()
+
+		// if the session was rejected but another request already logged in again, just use the new session
+		return (state.rejected && state.jwt == session.JWT) || session.expiresSoon()
+	}
+	if !needsRefresh() {
+		return nil
+	}
+
+	c.loginLock.Lock()
+	defer c.loginLock.Unlock()
+	if !needsRefresh() {
+		return nil
+	}
+	if c.credentials.Username == "" {
+		return errors.New("the session has expired and no credentials are available to log in again")
+	}
+	if c.credentials.MFAToken != "" && c.credentials.TOTPSecret == "" {
+		return errors.New("the session has expired and the one-time MFA token cannot be reused to log in again")
+	}
+	tflog.Debug(ctx, "session expired or was rejected - logging in again")
+	return c.authenticate(ctx)
+}
+
+// retryRejectedSession is a retry condition which retries an authenticated request once if the UDM rejected its
+// session.  Requests authenticated with an API key are never retried as there is no session to refresh.
+func (c *Client) retryRejectedSession(resp *resty.Response, _ error) bool {
+	if resp == nil {
+		return false
+	}
+	state, ok := resp.Request.Context().Value(authenticatedRequestKey{}).(*authenticatedRequest)
+	if !ok || state.retried || c.apiKey != "" {
+		return false
+	}
+	if statusCode := resp.StatusCode(); statusCode != http.StatusUnauthorized && statusCode != http.StatusForbidden {
+		return false
+	}
+	state.rejected = true
+	state.retried = true
+	return true
+}
+
+// session returns a copy of the current session data.
+func (c *Client) session() authData {
+	c.authLock.RLock()
+	defer c.authLock.RUnlock()
+	return c.authData
+}
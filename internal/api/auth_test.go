@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestLogin(t *testing.T) {
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+
+	session := c.session()
+	if session.JWT == "" || session.CSRFToken == "" {
+		t.Errorf("session is missing the JWT or CSRF token: %+v", session)
+	}
+	if session.ExpiresAt.IsZero() {
+		t.Error("session expiration time was not parsed from the JWT")
+	}
+	if session.User.Username != udmmock.DefaultUsername {
+		t.Errorf("session username = %q, want %q", session.User.Username, udmmock.DefaultUsername)
+	}
+	if c.networkAppInfo.System.Version != udmmock.DefaultNetworkAppVersion {
+		t.Errorf("network app version = %q, want %q", c.networkAppInfo.System.Version,
+			udmmock.DefaultNetworkAppVersion)
+	}
+}
+
+func TestLoginInvalidCredentials(t *testing.T) {
+	server := newTestServer(t, udmmock.Config{})
+	c := newTestClient(t, server)
+
+	err := c.Login(context.Background(), Credentials{
+		Username: udmmock.DefaultUsername,
+		Password: "wrong",
+	})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Login() error = %v, want an *Error", err)
+	}
+	if apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status code = %d, want %d", apiErr.StatusCode, http.StatusUnauthorized)
+	}
+	if apiErr.Code != "AUTHENTICATION_FAILED_INVALID_CREDENTIALS" {
+		t.Errorf("code = %q, want AUTHENTICATION_FAILED_INVALID_CREDENTIALS", apiErr.Code)
+	}
+}
+
+func TestLoginMFA(t *testing.T) {
+	const secret = "JBSWY3DPEHPK3PXP"
+	server := newTestServer(t, udmmock.Config{TOTPSecret: secret})
+
+	tests := []struct {
+		name    string
+		creds   Credentials
+		wantErr error
+	}{
+		{
+			name:    "missing code",
+			creds:   Credentials{},
+			wantErr: ErrMFARequired,
+		},
+		{
+			name:  "TOTP secret",
+			creds: Credentials{TOTPSecret: secret},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.creds.Username = udmmock.DefaultUsername
+			tt.creds.Password = udmmock.DefaultPassword
+			err := newTestClient(t, server).Login(context.Background(), tt.creds)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Login() returned an error: %s", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Login() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUseAPIKey(t *testing.T) {
+	server := newTestServer(t, udmmock.Config{APIKey: "test-api-key"})
+
+	if err := newTestClient(t, server).UseAPIKey(context.Background(), "test-api-key"); err != nil {
+		t.Errorf("UseAPIKey() returned an error: %s", err)
+	}
+	if got := server.RequestCount(http.MethodPost, "/api/auth/login"); got != 0 {
+		t.Errorf("login requests = %d, want 0", got)
+	}
+
+	err := newTestClient(t, server).UseAPIKey(context.Background(), "wrong")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("UseAPIKey() error = %v, want an *Error with HTTP 401", err)
+	}
+}
+
+func TestRejectedSessionIsRefreshed(t *testing.T) {
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+
+	server.ExpireSessions()
+	if _, err := c.GetStaticDNSRecords(context.Background(), DefaultSite); err != nil {
+		t.Fatalf("GetStaticDNSRecords() returned an error: %s", err)
+	}
+	if got := server.RequestCount(http.MethodPost, "/api/auth/login"); got != 2 {
+		t.Errorf("login requests = %d, want 2", got)
+	}
+}
+
+func TestShortLivedSessionIsRefreshedBeforeEveryRequest(t *testing.T) {
+	// sessions which expire within the refresh window are refreshed before every request
+	server := newTestServer(t, udmmock.Config{SessionLifetime: sessionRefreshWindow / 2})
+	c := newLoggedInClient(t, server)
+
+	if _, err := c.GetStaticDNSRecords(context.Background(), DefaultSite); err != nil {
+		t.Fatalf("GetStaticDNSRecords() returned an error: %s", err)
+	}
+	if got := server.RequestCount(http.MethodPost, "/api/auth/login"); got != 3 {
+		t.Errorf("login requests = %d, want 3", got)
+	}
+	if c.session().ExpiresAt.Before(time.Now()) {
+		t.Error("session was not refreshed")
+	}
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	collectionClientDevices    = "rest/user"  // Cache name of the client device collection
+	collectionSites            = "stat/sites" // Cache key of the site collection, which does not belong to a site
+	collectionStaticDNSRecords = "static-dns" // Cache name of the static DNS record collection
+)
+
+// collectionCache caches the responses of list requests for the lifetime of the client.
+//
+// Resources are refreshed one at a time while the UDM API can only list whole collections, so without the cache every
+// refreshed resource would fetch its entire collection again.  Concurrent fetches of the same collection are
+// collapsed into a single request and a collection is dropped from the cache whenever it is modified.
+type collectionCache struct {
+	entries map[string]*collectionCacheEntry
+	lock    sync.Mutex
+}
+
+// collectionCacheEntry holds a cached collection, which is only valid once done is closed.
+type collectionCacheEntry struct {
+	done  chan struct{}
+	err   error
+	value any
+}
+
+// newCollectionCache creates a new, empty cache.
+func newCollectionCache() *collectionCache {
+	return &collectionCache{
+		entries: map[string]*collectionCacheEntry{},
+	}
+}
+
+// collectionCacheKey returns the cache key of a collection within a site.
+func collectionCacheKey(site, collection string) string {
+	return fmt.Sprintf("%s/%s", site, collection)
+}
+
+// get returns the cached collection with the given key, calling fetch to retrieve it if it is not cached.
+//
+// If another caller is already fetching the collection, get waits for that fetch to finish instead.  Errors are not
+// cached.
+func (cc *collectionCache) get(key string, fetch func() (any, error)) (any, bool, error) {
+	cc.lock.Lock()
+	if entry, ok := cc.entries[key]; ok {
+		cc.lock.Unlock()
+		<-entry.done
+		return entry.value, true, entry.err
+	}
+	entry := &collectionCacheEntry{
+		done: make(chan struct{}),
+	}
+	cc.entries[key] = entry
+	cc.lock.Unlock()
+
+	entry.value, entry.err = fetch()
+	close(entry.done)
+	if entry.err != nil {
+		cc.invalidateEntry(key, entry)
+	}
+	return entry.value, false, entry.err
+}
+
+// invalidate drops the collection with the given key from the cache.
+func (cc *collectionCache) invalidate(key string) {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+	delete(cc.entries, key)
+}
+
+// invalidateEntry drops the given entry from the cache unless it was already replaced.
+func (cc *collectionCache) invalidateEntry(key string, entry *collectionCacheEntry) {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+	if cc.entries[key] == entry {
+		delete(cc.entries, key)
+	}
+}
+
+// cachedCollection returns a copy of the cached collection with the given key, calling fetch to retrieve it if it is
+// not cached.
+func cachedCollection[T any](ctx context.Context, c *Client, key string, fetch func() ([]T, error)) ([]T, error) {
+	value, cached, err := c.cache.get(key, func() (any, error) {
+		return fetch()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		tflog.Debug(ctx, "using cached collection", map[string]any{
+			"collection": key,
+		})
+	}
+
+	// callers are free to modify the returned slice, so never hand out the cached one
+	items, _ := value.([]T)
+	return slices.Clone(items), nil
+}
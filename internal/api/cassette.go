@@ -0,0 +1,230 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	// cassetteSessionExpiry is the expiration time of the JWT which replaces the recorded one.  It lies far in the
+	// future so a replayed session never has to be refreshed.
+	cassetteSessionExpiry = time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// cassetteScrubbedLoginFields are the fields of the login request and response bodies whose values are replaced
+	// when recording in addition to the sensitive fields of every body.
+	cassetteScrubbedLoginFields = []string{"email", "username"}
+)
+
+// cassette holds the HTTP interactions recorded from a UDM.
+type cassette struct {
+	Interactions []cassetteInteraction `json:"interactions"`
+}
+
+// cassetteInteraction is a single recorded request and its response.
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Body   string      `json:"body,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Method string      `json:"method"`
+	URL    string      `json:"url"` // path and query only so cassettes do not depend on the UDM's hostname
+}
+
+type cassetteResponse struct {
+	Body       string      `json:"body,omitempty"`
+	Header     http.Header `json:"header,omitempty"`
+	StatusCode int         `json:"status_code"`
+}
+
+// recordingTransport is an http.RoundTripper which saves every request sent through it along with its response to a
+// cassette file.
+//
+// Secrets are scrubbed before the interaction is saved: the JWT cookie is replaced by a stand-in with a dummy signature
+// which never expires so that replaying the login still works, while sensitive headers, the login credentials and the
+// values of sensitive JSON fields (see isSensitiveJSONField) are replaced by a placeholder.  The file is rewritten
+// after every interaction so it is complete even if Terraform is interrupted.
+type recordingTransport struct {
+	cassette cassette
+	filename string
+	lock     sync.Mutex
+	next     http.RoundTripper
+}
+
+// newRecordingTransport wraps next with a transport recording to the given cassette file.
+func newRecordingTransport(next http.RoundTripper, filename string) *recordingTransport {
+	return &recordingTransport{
+		filename: filename,
+		next:     next,
+	}
+}
+
+// RoundTrip executes a single HTTP transaction and records it.  Requests which fail without a response are not
+// recorded.
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		reqBody, err = io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction, err := scrubInteraction(req, reqBody, resp, respBody)
+	if err != nil {
+		return nil, err
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction)
+	data, err := json.MarshalIndent(t.cassette, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(t.filename, data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to save cassette: %w", err)
+	}
+	return resp, nil
+}
+
+// replayingTransport is an http.RoundTripper which answers requests from a cassette file recorded by
+// recordingTransport instead of sending them to the UDM.
+//
+// Each request is answered with the first interaction not replayed yet which has the same method and URL, so the
+// same request can be answered differently as the recorded state changes (eg: before and after a create).
+type replayingTransport struct {
+	cassette cassette
+	filename string
+	lock     sync.Mutex
+	replayed []bool
+}
+
+// newReplayingTransport creates a transport replaying the given cassette file.
+func newReplayingTransport(filename string) (*replayingTransport, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	t := &replayingTransport{
+		filename: filename,
+	}
+	if err := json.Unmarshal(data, &t.cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette '%s': %w", filename, err)
+	}
+	t.replayed = make([]bool, len(t.cassette.Interactions))
+	return t, nil
+}
+
+// RoundTrip answers a request with its recorded response.
+func (t *replayingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for i, interaction := range t.cassette.Interactions {
+		recorded := interaction.Request
+		if t.replayed[i] || recorded.Method != req.Method || recorded.URL != req.URL.RequestURI() {
+			continue
+		}
+		t.replayed[i] = true
+		statusCode := interaction.Response.StatusCode
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+			StatusCode:    statusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no interaction left to replay for %s %s in cassette '%s'", req.Method,
+		req.URL.RequestURI(), t.filename)
+}
+
+// scrubInteraction creates the interaction to record for a request and its response with all secrets replaced.
+func scrubInteraction(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte) (
+	cassetteInteraction, error) {
+
+	interaction := cassetteInteraction{
+		Request: cassetteRequest{
+			Header: redactHeaders(req.Header),
+			Method: req.Method,
+			URL:    req.URL.RequestURI(),
+		},
+		Response: cassetteResponse{
+			Header:     redactHeaders(resp.Header),
+			StatusCode: resp.StatusCode,
+		},
+	}
+	if interaction.Request.Header.Get("Cookie") != "" {
+		interaction.Request.Header.Set("Cookie", JWTCookieName+"="+redactedValue)
+	}
+
+	// every cookie set by the UDM is scrubbed, but the JWT must still be parsable to replay the login
+	if cookies := resp.Cookies(); len(cookies) > 0 {
+		jwtStandIn, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(cassetteSessionExpiry),
+			},
+			CsrfToken: redactedValue,
+		}).SignedString([]byte(redactedValue))
+		if err != nil {
+			return cassetteInteraction{}, err
+		}
+		interaction.Response.Header.Del("Set-Cookie")
+		for _, cookie := range cookies {
+			cookie.Value = redactedValue
+			if cookie.Name == JWTCookieName {
+				cookie.Value = jwtStandIn
+				cookie.Expires = cassetteSessionExpiry
+			}
+			interaction.Response.Header.Add("Set-Cookie", cookie.String())
+		}
+	}
+
+	sensitive := isSensitiveJSONField
+	if req.URL.Path == "/api/auth/login" {
+		sensitive = func(key string) bool {
+			return isSensitiveJSONField(key) || slices.Contains(cassetteScrubbedLoginFields, key)
+		}
+	}
+	if len(reqBody) > 0 {
+		interaction.Request.Body = string(redactJSON(reqBody, sensitive))
+	}
+	if len(respBody) > 0 {
+		interaction.Response.Body = string(redactJSON(respBody, sensitive))
+		interaction.Response.Header.Del("Content-Length")
+	}
+	return interaction, nil
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "cassette.json")
+	creds := Credentials{
+		Username: udmmock.DefaultUsername,
+		Password: "correct-horse-battery-staple",
+	}
+
+	// record
+	server := newTestServer(t, udmmock.Config{Password: creds.Password})
+	config := testClientConfig(server)
+	config.RecordCassette = filename
+	c, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() returned an error: %s", err)
+	}
+	if err := c.Login(ctx, creds); err != nil {
+		t.Fatalf("Login() returned an error: %s", err)
+	}
+	created, err := c.CreateStaticDNSRecord(ctx, DefaultSite, StaticDNSRecord{
+		Enabled:    true,
+		Key:        "nas.example.com",
+		RecordType: "A",
+		Value:      "192.168.1.10",
+	})
+	if err != nil {
+		t.Fatalf("CreateStaticDNSRecord() returned an error: %s", err)
+	}
+	recorded, err := c.GetStaticDNSRecords(ctx, DefaultSite)
+	if err != nil {
+		t.Fatalf("GetStaticDNSRecords() returned an error: %s", err)
+	}
+	session := c.session()
+	server.Close()
+
+	// no secrets may end up in the cassette
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read cassette: %s", err)
+	}
+	for name, secret := range map[string]string{
+		"JWT":        session.JWT,
+		"CSRF token": session.CSRFToken,
+		"password":   creds.Password,
+	} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains the %s", name)
+		}
+	}
+
+	// replay without the server
+	config.RecordCassette = ""
+	config.ReplayCassette = filename
+	c, err = NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() returned an error: %s", err)
+	}
+	if err := c.Login(ctx, creds); err != nil {
+		t.Fatalf("Login() from cassette returned an error: %s", err)
+	}
+	if _, err := c.CreateStaticDNSRecord(ctx, DefaultSite, StaticDNSRecord{}); err != nil {
+		t.Fatalf("CreateStaticDNSRecord() from cassette returned an error: %s", err)
+	}
+	replayed, err := c.GetStaticDNSRecords(ctx, DefaultSite)
+	if err != nil {
+		t.Fatalf("GetStaticDNSRecords() from cassette returned an error: %s", err)
+	}
+	if !slices.Equal(replayed, recorded) || len(replayed) != 1 || replayed[0].ID != created.ID {
+		t.Errorf("GetStaticDNSRecords() from cassette = %+v, want %+v", replayed, recorded)
+	}
+
+	// every interaction is only replayed once
+	if err := c.DeleteStaticDNSRecord(ctx, DefaultSite, created.ID); err == nil {
+		t.Error("DeleteStaticDNSRecord() which was not recorded did not return an error")
+	}
+}
+
+func TestCassetteRecordAndReplayConflict(t *testing.T) {
+	_, err := NewClient(ClientConfig{
+		Hostname:       "udm.example.com",
+		RecordCassette: "record.json",
+		ReplayCassette: "replay.json",
+	})
+	if err == nil {
+		t.Error("NewClient() with both a cassette to record and to replay did not return an error")
+	}
+}
@@ -2,11 +2,13 @@ package api
 
 import (
 	"context"
-	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -14,43 +16,146 @@ import (
 )
 
 type Client struct {
+	apiKey         string
 	authData       authData
+	authLock       sync.RWMutex
+	cache          *collectionCache
 	cli            *resty.Client
+	credentials    Credentials
 	hostname       string
+	loginLock      sync.Mutex
 	networkAppInfo NetworkAppInfo
+	retryPolicy    RetryPolicy
 	site           string
 }
 
-func NewClient(hostname, site string, ignoreUntrustedSSLCert bool) *Client {
-	cli := resty.New().SetTLSClientConfig(&tls.Config{
-		InsecureSkipVerify: ignoreUntrustedSSLCert,
-	})
-	return &Client{
-		cli:      cli,
-		hostname: hostname,
-		site:     site,
+// ClientConfig holds the settings used to create a Client.
+type ClientConfig struct {
+	// Hostname is the hostname or IP address of the UDM.
+	Hostname string
+
+	// Site is the name of the default site managed through the client.
+	Site string
+
+	// IgnoreUntrustedSSLCert disables verification of the UDM's certificate entirely.
+	IgnoreUntrustedSSLCert bool
+
+	// CACertificatePEM holds the PEM-encoded CA certificates to trust instead of the system roots.
+	CACertificatePEM []byte
+
+	// TLSFingerprintSHA256 pins the SHA-256 fingerprint of the UDM's certificate.
+	TLSFingerprintSHA256 string
+
+	// MaxRequestsPerSecond limits the rate at which requests are sent.  A value of 0 means no limit.
+	MaxRequestsPerSecond float64
+
+	// MaxConcurrentRequests limits the number of requests in flight at once.  A value of 0 means no limit.
+	MaxConcurrentRequests int
+
+	// RetryPolicy controls how requests which fail with a transient error are retried.  DefaultRetryPolicy is used
+	// if it is not set.
+	RetryPolicy *RetryPolicy
+
+	// RecordCassette is the name of a file to which every request and its response are recorded with any secrets
+	// scrubbed.
+	RecordCassette string
+
+	// ReplayCassette is the name of a file recorded with RecordCassette from which responses are replayed instead of
+	// sending requests to the UDM.
+	ReplayCassette string
+}
+
+func NewClient(config ClientConfig) (*Client, error) {
+	if config.RecordCassette != "" && config.ReplayCassette != "" {
+		return nil, errors.New("a cassette cannot be recorded and replayed at the same time")
+	}
+	tlsConfig, err := newTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		cache:       newCollectionCache(),
+		hostname:    config.Hostname,
+		retryPolicy: DefaultRetryPolicy(),
+		site:        config.Site,
+	}
+	if config.RetryPolicy != nil {
+		c.retryPolicy = *config.RetryPolicy
+	}
+
+	// every request - including logins and retries - shares the same rate limiter and concurrency cap
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	// for debugging, the exchanges with the UDM can be recorded to a cassette or replayed from one instead of
+	// contacting the UDM - the cassette sits below the limiter so every attempt of a request is recorded
+	var base http.RoundTripper = transport
+	switch {
+	case config.ReplayCassette != "":
+		if base, err = newReplayingTransport(config.ReplayCassette); err != nil {
+			return nil, err
+		}
+	case config.RecordCassette != "":
+		base = newRecordingTransport(transport, config.RecordCassette)
 	}
+
+	// authenticated requests pick up the current session right before they are sent and are retried once with a
+	// new session if the UDM rejects the one they were sent with - any request is retried with an exponential
+	// backoff if it fails with a transient error, so resty allows for the retries of the retry policy plus the one
+	// retry with a new session, which does not count towards the policy's attempts
+	c.cli = resty.New().
+		SetTransport(newLimitedTransport(base, config.MaxRequestsPerSecond, config.MaxConcurrentRequests)).
+		OnBeforeRequest(c.applySession).
+		SetRetryCount(c.retryPolicy.MaxAttempts).
+		SetRetryWaitTime(c.retryPolicy.MinWait).
+		SetRetryMaxWaitTime(c.retryPolicy.MaxWait).
+		AddRetryCondition(c.retryRejectedSession).
+		AddRetryCondition(c.retryTransientFailure)
+	return c, nil
 }
 
 func (c *Client) addClientContext(ctx context.Context) context.Context {
 	ctx = tflog.SetField(ctx, "hostname", c.hostname)
-	ctx = tflog.SetField(ctx, "site", c.site)
-	ctx = tflog.SetField(ctx, "username", c.authData.User.Username)
+	ctx = tflog.SetField(ctx, "username", c.session().User.Username)
 	ctx = tflog.SetField(ctx, "system_name", c.networkAppInfo.System.Name)
 	ctx = tflog.SetField(ctx, "unifi_console_version", c.networkAppInfo.System.UnifiConsole.Version)
 	ctx = tflog.SetField(ctx, "network_app_version", c.networkAppInfo.System.Version)
+
+	// as a last line of defense, the secrets currently in use never make it into a log entry verbatim
+	var secrets []string
+	session := c.session()
+	for _, secret := range []string{c.apiKey, session.JWT, session.CSRFToken} {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	if len(secrets) > 0 {
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, secrets...)
+		ctx = tflog.MaskMessageStrings(ctx, secrets...)
+	}
 	return ctx
 }
 
+// addSiteContext adds the client's log fields along with the site a request is made for to the context.
+func (c *Client) addSiteContext(ctx context.Context, site string) context.Context {
+	return tflog.SetField(c.addClientContext(ctx), "site", site)
+}
+
+// DefaultSite returns the name of the site used when a resource or data source does not name one.
+func (c *Client) DefaultSite() string {
+	return c.site
+}
+
 func (c *Client) logResponse(ctx context.Context, resp *resty.Response) (int, http.Header, []byte) {
 	statusCode := resp.StatusCode()
 	httpHeaders := resp.Header()
 	body := resp.Body()
 
 	if strings.EqualFold(os.Getenv("TF_LOG"), "DEBUG") || strings.EqualFold(os.Getenv("TF_LOG"), "TRACE") {
+		// secrets are redacted as the log may be shared when reporting issues
 		headers := map[string]any{}
 		cookies := map[string]map[string]any{}
-		for k, v := range resp.Header() {
+		for k, v := range redactHeaders(resp.Header()) {
 			if k == "Set-Cookie" {
 				continue
 			}
@@ -58,7 +163,7 @@ func (c *Client) logResponse(ctx context.Context, resp *resty.Response) (int, ht
 		}
 		for _, cookie := range resp.Cookies() {
 			cookies[cookie.Name] = map[string]any{
-				"Value":    cookie.Value,
+				"Value":    redactedValue,
 				"Path":     cookie.Path,
 				"Domain":   cookie.Domain,
 				"Expires":  cookie.Expires.Format(time.RFC3339),
@@ -68,34 +173,49 @@ func (c *Client) logResponse(ctx context.Context, resp *resty.Response) (int, ht
 			}
 		}
 		tflog.Debug(ctx, fmt.Sprintf("response received from: %s", resp.Request.URL), map[string]any{
-			"status_code": statusCode,
-			"headers":     headers,
-			"cookies":     cookies,
-			"body":        string(body),
+			"method":       resp.Request.Method,
+			"request_body": requestBody(resp.Request),
+			"status_code":  statusCode,
+			"headers":      headers,
+			"cookies":      cookies,
+			"body":         string(redactJSON(body, isSensitiveJSONField)),
 		})
 	}
 	return statusCode, httpHeaders, body
 }
 
+// requestBody returns the body sent with a request as a string with the values of sensitive fields redacted.
+func requestBody(req *resty.Request) string {
+	var data []byte
+	switch body := req.Body.(type) {
+	case nil:
+		return ""
+	case []byte:
+		data = body
+	case string:
+		data = []byte(body)
+	default:
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return fmt.Sprintf("<unable to encode request body: %s>", err)
+		}
+	}
+	return string(redactJSON(data, isSensitiveJSONField))
+}
+
 func (c *Client) newAuthenticatedRequest(ctx context.Context, uri string) (string, *resty.Request) {
 	url, req := c.newRequest(ctx, uri)
-	return url,
-		req.
-			SetHeader("X-Csrf-Token", c.authData.CSRFToken).
-			SetCookie(&http.Cookie{
-				Name:     JWTCookieName,
-				Value:    c.authData.JWT,
-				Path:     "/",
-				Domain:   c.hostname,
-				Secure:   true,
-				HttpOnly: true,
-				SameSite: http.SameSiteNoneMode,
-			})
+
+	// the API key or session itself is added by applySession
+	return url, req.SetContext(context.WithValue(req.Context(), authenticatedRequestKey{}, &authenticatedRequest{}))
 }
 
-func (c *Client) newRequest(_ context.Context, uri string) (string, *resty.Request) {
+func (c *Client) newRequest(ctx context.Context, uri string) (string, *resty.Request) {
+	// requests made on behalf of an authenticated request (eg: logging in again) must not inherit its session state
+	ctx = context.WithValue(ctx, authenticatedRequestKey{}, nil)
 	return fmt.Sprintf("https://%s%s", c.hostname, uri),
 		c.cli.R().
+			SetContext(context.WithValue(ctx, requestStartKey{}, time.Now())).
 			SetHeader("Content-Type", "application/json").
 			SetHeader("Accept", "application/json")
 }
@@ -15,12 +15,17 @@ type createClientDeviceResponseSuccess struct {
 	Data []ClientDevice `json:"data"`
 }
 
-type createClientDeviceResponseError struct {
+type deleteClientDeviceRequest struct {
+	Command           string   `json:"cmd"`
+	HardwareAddresses []string `json:"macs"`
+}
+
+type deleteClientDeviceResponseSuccess struct {
 	Meta struct {
 		RC      string `json:"rc"`
 		Message string `json:"msg"`
-	}
-	Data []ClientDevice `json:"data"`
+	} `json:"meta"`
+	Data []any `json:"data"`
 }
 
 type getClientDevicesResponseSuccess struct {
@@ -31,11 +36,11 @@ type getClientDevicesResponseSuccess struct {
 	Data []ClientDevice `json:"data"`
 }
 
-type getClientDevicesResponseError struct {
+type updateClientDeviceResponseSuccess struct {
 	Meta struct {
 		RC      string `json:"rc"`
 		Message string `json:"msg"`
-	}
+	} `json:"meta"`
 	Data []ClientDevice `json:"data"`
 }
 
@@ -64,37 +69,38 @@ type ClientDevice struct {
 	LastUplinkHardwareAddress     string   `json:"last_uplink_mac,omitempty"`
 	LastUplinkName                string   `json:"last_uplink_name,omitempty"`
 	LocalDNSRecord                string   `json:"local_dns_record,omitempty"`
-	LocalDNSRecordEnabled         bool     `json:"local_dns_record_enabled,omitempty"`
+	LocalDNSRecordEnabled         bool     `json:"local_dns_record_enabled"`
 	Name                          string   `json:"name,omitempty"`
 	Noted                         bool     `json:"noted,omitempty"`
 	OSClass                       int      `json:"os_class,omitempty"`
 	OSName                        int      `json:"os_name,omitempty"`
 	Oui                           string   `json:"oui,omitempty"`
 	SiteID                        string   `json:"site_id,omitempty"`
-	UseFixedIP                    bool     `json:"use_fixedip,omitempty"`
+	UseFixedIP                    bool     `json:"use_fixedip"`
 	UsergroupID                   string   `json:"usergroup_id,omitempty"`
 	VirtualNetworkOverrideEnabled bool     `json:"virtual_network_override_enabled,omitempty"`
 	VirtualNetworkOverrideID      string   `json:"virtual_network_override_id"`
 	WLANConfigID                  string   `json:"wlanconf_id,omitempty"`
 }
 
-func (c *Client) CreateClientDevice(ctx context.Context, device ClientDevice) (ClientDevice, error) {
-	ctx = c.addClientContext(ctx)
+func (c *Client) CreateClientDevice(ctx context.Context, site string, device ClientDevice) (ClientDevice, error) {
+	ctx = c.addSiteContext(ctx, site)
 	ctx = tflog.SetField(ctx, "hardware_address", device.HardwareAddress)
 
 	// POST /proxy/network/api/s/:site/rest/user
-	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/api/s/%s/rest/user", c.site))
+	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/api/s/%s/rest/user", site))
 	tflog.Debug(ctx, "creating client device record", map[string]any{
 		"url":    url,
-		"device": device,
+		"device": redactObject(device),
 	})
 	apiResponseSuccess := createClientDeviceResponseSuccess{}
-	apiResponseError := createClientDeviceResponseError{}
+	apiResponseError := errorResponse{}
 	resp, err := req.
 		SetBody(device).
 		SetResult(&apiResponseSuccess).
 		SetError(&apiResponseError).
 		Post(url)
+	c.cache.invalidate(collectionCacheKey(site, collectionClientDevices))
 	if err != nil {
 		tflog.Error(ctx, "failed to execute POST request", map[string]any{
 			"error_message": err.Error(),
@@ -105,25 +111,74 @@ func (c *Client) CreateClientDevice(ctx context.Context, device ClientDevice) (C
 
 	// if a non-200 error was returned by the API, something went wrong
 	if statusCode != 200 {
-		tflog.Error(ctx, "failed to create client device", map[string]any{
-			"message":     apiResponseError.Meta.Message,
-			"return_code": apiResponseError.Meta.RC,
-		})
-		return ClientDevice{}, fmt.Errorf("failed to create client device: %s", apiResponseError.Meta.Message)
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to create client device", apiErr.logFields())
+		return ClientDevice{}, fmt.Errorf("failed to create client device: %w", apiErr)
+	}
+	if len(apiResponseSuccess.Data) == 0 {
+		tflog.Error(ctx, "no client device was returned by the API")
+		return ClientDevice{}, fmt.Errorf("failed to create client device: no device was returned by the API")
 	}
 	return ClientDevice(apiResponseSuccess.Data[0]), nil
 }
 
-func (c *Client) GetClientDevices(ctx context.Context) ([]ClientDevice, error) {
-	ctx = c.addClientContext(ctx)
+func (c *Client) DeleteClientDevice(ctx context.Context, site, hardwareAddress string) error {
+	ctx = c.addSiteContext(ctx, site)
+	ctx = tflog.SetField(ctx, "hardware_address", hardwareAddress)
+
+	// client devices cannot be deleted directly - instead the controller is told to forget the device, which
+	// removes the client record along with any fixed IP or local DNS record assigned to it
+	//
+	// POST /proxy/network/api/s/:site/cmd/stamgr
+	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/api/s/%s/cmd/stamgr", site))
+	tflog.Debug(ctx, "forgetting client device", map[string]any{
+		"url": url,
+	})
+	apiResponseSuccess := deleteClientDeviceResponseSuccess{}
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetBody(deleteClientDeviceRequest{
+			Command:           "forget-sta",
+			HardwareAddresses: []string{hardwareAddress},
+		}).
+		SetResult(&apiResponseSuccess).
+		SetError(&apiResponseError).
+		Post(url)
+	c.cache.invalidate(collectionCacheKey(site, collectionClientDevices))
+	if err != nil {
+		tflog.Error(ctx, "failed to execute POST request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to delete client device", apiErr.logFields())
+		return fmt.Errorf("failed to delete client device: %w", apiErr)
+	}
+	return nil
+}
+
+func (c *Client) GetClientDevices(ctx context.Context, site string) ([]ClientDevice, error) {
+	ctx = c.addSiteContext(ctx, site)
+	key := collectionCacheKey(site, collectionClientDevices)
+	return cachedCollection(ctx, c, key, func() ([]ClientDevice, error) {
+		return c.listClientDevices(ctx, site)
+	})
+}
 
+// listClientDevices retrieves all client devices from the UDM, bypassing the cache.
+func (c *Client) listClientDevices(ctx context.Context, site string) ([]ClientDevice, error) {
 	// GET /proxy/network/api/s/:site/rest/user
-	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/api/s/%s/rest/user", c.site))
+	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/api/s/%s/rest/user", site))
 	tflog.Debug(ctx, "retrieving client devices", map[string]any{
 		"url": url,
 	})
 	apiResponseSuccess := getClientDevicesResponseSuccess{}
-	apiResponseError := getClientDevicesResponseError{}
+	apiResponseError := errorResponse{}
 	resp, err := req.
 		SetResult(&apiResponseSuccess).
 		SetError(&apiResponseError).
@@ -138,30 +193,71 @@ func (c *Client) GetClientDevices(ctx context.Context) ([]ClientDevice, error) {
 
 	// if a non-200 error was returned by the API, something went wrong
 	if statusCode != 200 {
-		tflog.Error(ctx, "failed to retrieve client devices", map[string]any{
-			"body": resp.Body(),
-		})
-		return nil, fmt.Errorf("failed to retrieve client devices")
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to retrieve client devices", apiErr.logFields())
+		return nil, fmt.Errorf("failed to retrieve client devices: %w", apiErr)
 	}
 	return []ClientDevice(apiResponseSuccess.Data), nil
 }
 
-func (c *Client) GetClientDevice(ctx context.Context, id string) (ClientDevice, error) {
+func (c *Client) GetClientDevice(ctx context.Context, site, id string) (ClientDevice, error) {
 	ctx = tflog.SetField(ctx, "id", id)
-	devices, err := c.GetClientDevices(ctx)
+	devices, err := c.GetClientDevices(ctx, site)
 	if err != nil {
 		return ClientDevice{}, err
 	}
-	ctx = c.addClientContext(ctx)
+	ctx = c.addSiteContext(ctx, site)
 
 	// find the ID in question
 	tflog.Debug(ctx, "searching for client device")
 	for _, device := range devices {
 		if device.ID == id {
-			tflog.Debug(ctx, "client device was located", map[string]any{"device": device})
+			tflog.Debug(ctx, "client device was located", map[string]any{"device": redactObject(device)})
 			return device, nil
 		}
 	}
 	tflog.Warn(ctx, "client device not found")
-	return ClientDevice{}, fmt.Errorf("no client device found with an ID of '%s'", id)
+	return ClientDevice{}, fmt.Errorf("%w: no client device found with an ID of '%s'", ErrNotFound, id)
+}
+
+func (c *Client) UpdateClientDevice(ctx context.Context, site, id string, device ClientDevice) (
+	ClientDevice, error) {
+
+	ctx = c.addSiteContext(ctx, site)
+	ctx = tflog.SetField(ctx, "id", id)
+	ctx = tflog.SetField(ctx, "hardware_address", device.HardwareAddress)
+
+	// PUT /proxy/network/api/s/:site/rest/user/:id
+	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/api/s/%s/rest/user/%s", site, id))
+	tflog.Debug(ctx, "updating client device record", map[string]any{
+		"url":    url,
+		"device": redactObject(device),
+	})
+	apiResponseSuccess := updateClientDeviceResponseSuccess{}
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetBody(device).
+		SetResult(&apiResponseSuccess).
+		SetError(&apiResponseError).
+		Put(url)
+	c.cache.invalidate(collectionCacheKey(site, collectionClientDevices))
+	if err != nil {
+		tflog.Error(ctx, "failed to execute PUT request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return ClientDevice{}, err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to update client device", apiErr.logFields())
+		return ClientDevice{}, fmt.Errorf("failed to update client device: %w", apiErr)
+	}
+	if len(apiResponseSuccess.Data) == 0 {
+		tflog.Error(ctx, "no client device was returned by the API")
+		return ClientDevice{}, fmt.Errorf("failed to update client device: no device was returned by the API")
+	}
+	return apiResponseSuccess.Data[0], nil
 }
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestClientDeviceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+
+	created, err := c.CreateClientDevice(ctx, DefaultSite, ClientDevice{
+		HardwareAddress: "AA:BB:CC:DD:EE:FF",
+		Name:            "printer",
+		FixedIP:         "192.168.1.50",
+		UseFixedIP:      true,
+	})
+	if err != nil {
+		t.Fatalf("CreateClientDevice() returned an error: %s", err)
+	}
+	if created.ID == "" || created.HardwareAddress != "aa:bb:cc:dd:ee:ff" {
+		t.Fatalf("CreateClientDevice() = %+v, want an ID and a lowercase MAC address", created)
+	}
+
+	update := created
+	update.Name = "office-printer"
+	update.UseFixedIP = false
+	if _, err := c.UpdateClientDevice(ctx, DefaultSite, created.ID, update); err != nil {
+		t.Fatalf("UpdateClientDevice() returned an error: %s", err)
+	}
+	got, err := c.GetClientDevice(ctx, DefaultSite, created.ID)
+	if err != nil {
+		t.Fatalf("GetClientDevice() returned an error: %s", err)
+	}
+	if got.Name != update.Name || got.UseFixedIP {
+		t.Errorf("GetClientDevice() after update = %+v, want name %q without a fixed IP", got, update.Name)
+	}
+
+	if err := c.DeleteClientDevice(ctx, DefaultSite, created.HardwareAddress); err != nil {
+		t.Fatalf("DeleteClientDevice() returned an error: %s", err)
+	}
+	if _, err := c.GetClientDevice(ctx, DefaultSite, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetClientDevice() after delete error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestCreateClientDeviceDuplicateMAC(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+	server.AddObject(udmmock.DefaultSite, udmmock.CollectionClientDevices, map[string]any{
+		"mac": "aa:bb:cc:dd:ee:ff",
+	})
+
+	_, err := c.CreateClientDevice(ctx, DefaultSite, ClientDevice{HardwareAddress: "aa:bb:cc:dd:ee:ff"})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("CreateClientDevice() error = %v, want an *Error", err)
+	}
+	if apiErr.ReturnCode != "error" || apiErr.Message != "api.err.MacUsed" {
+		t.Errorf("CreateClientDevice() error = %+v, want rc \"error\" and msg \"api.err.MacUsed\"", apiErr)
+	}
+}
+
+func TestClientDeviceEmptyResponse(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+	created, err := c.CreateClientDevice(ctx, DefaultSite, ClientDevice{HardwareAddress: "aa:bb:cc:dd:ee:ff"})
+	if err != nil {
+		t.Fatalf("CreateClientDevice() returned an error: %s", err)
+	}
+
+	// a successful response without any device must be reported rather than indexed
+	path := "/proxy/network/api/s/default/rest/user"
+	server.FailRequests(http.MethodPost, path, http.StatusOK, 1)
+	if _, err := c.CreateClientDevice(ctx, DefaultSite, ClientDevice{HardwareAddress: "aa:bb:cc:dd:ee:00"}); err == nil {
+		t.Error("CreateClientDevice() with an empty response did not return an error")
+	}
+	server.FailRequests(http.MethodPut, path+"/"+created.ID, http.StatusOK, 1)
+	if _, err := c.UpdateClientDevice(ctx, DefaultSite, created.ID, created); err == nil {
+		t.Error("UpdateClientDevice() with an empty response did not return an error")
+	}
+}
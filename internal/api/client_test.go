@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+// newTestServer starts a mock UDM which is shut down when the test finishes.
+func newTestServer(t *testing.T, config udmmock.Config) *udmmock.Server {
+	t.Helper()
+	server := udmmock.NewServer(config)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// testClientConfig returns the configuration of a client for the given mock UDM which retries quickly so tests do
+// not have to wait.
+func testClientConfig(server *udmmock.Server) ClientConfig {
+	fingerprint := sha256.Sum256(server.Certificate().Raw)
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = 3
+	policy.MinWait = 10 * time.Millisecond
+	policy.MaxWait = 50 * time.Millisecond
+	return ClientConfig{
+		Hostname:             server.Hostname(),
+		Site:                 DefaultSite,
+		TLSFingerprintSHA256: FormatFingerprint(fingerprint[:]),
+		RetryPolicy:          &policy,
+	}
+}
+
+// newTestClient creates a client for the given mock UDM using testClientConfig.
+func newTestClient(t *testing.T, server *udmmock.Server) *Client {
+	t.Helper()
+	c, err := NewClient(testClientConfig(server))
+	if err != nil {
+		t.Fatalf("NewClient() returned an error: %s", err)
+	}
+	return c
+}
+
+// newLoggedInClient creates a client for the given mock UDM and logs in with the default credentials.
+func newLoggedInClient(t *testing.T, server *udmmock.Server) *Client {
+	t.Helper()
+	c := newTestClient(t, server)
+	err := c.Login(context.Background(), Credentials{
+		Username: udmmock.DefaultUsername,
+		Password: udmmock.DefaultPassword,
+	})
+	if err != nil {
+		t.Fatalf("Login() returned an error: %s", err)
+	}
+	return c
+}
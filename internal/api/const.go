@@ -1,6 +1,7 @@
 package api
 
 const (
-	DefaultSite   = "default" // Name of the default Ubiquiti site
-	JWTCookieName = "TOKEN"   // Name of cookie where JWT is stored
+	APIKeyHeaderName = "X-API-KEY" // Name of header where an API key is sent
+	DefaultSite      = "default"   // Name of the default Ubiquiti site
+	JWTCookieName    = "TOKEN"     // Name of cookie where JWT is stored
 )
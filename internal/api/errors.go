@@ -0,0 +1,129 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+var (
+	ErrMFARequired = errors.New("multi-factor authentication required") // Returned when the account requires MFA
+	ErrNotFound    = errors.New("object not found")                     // Returned when the requested object does not exist on the UDM
+)
+
+// errorResponse is the body returned by the UDM API when a request fails.
+//
+// The v1 API (/proxy/network/api) wraps errors in a "meta" object while the v2 API (/proxy/network/v2/api) and the
+// UniFi OS API (/api) return the error fields at the top level, so both shapes are decoded into the same struct.
+type errorResponse struct {
+	Meta struct {
+		RC      string `json:"rc"`
+		Message string `json:"msg"`
+	} `json:"meta"`
+	Code      string `json:"code"`
+	Details   any    `json:"details"`
+	ErrorCode int    `json:"errorCode"`
+	Message   string `json:"message"`
+}
+
+// Error is returned whenever the UDM API responds with an unsuccessful status code.
+//
+// Use errors.As to retrieve it from errors returned by the Client.
+type Error struct {
+	// Method is the HTTP method of the failed request.
+	Method string
+
+	// Endpoint is the path of the failed request.
+	Endpoint string
+
+	// StatusCode is the HTTP status code returned by the UDM.
+	StatusCode int
+
+	// ReturnCode is the "meta.rc" value returned by the v1 API.
+	ReturnCode string
+
+	// Message is the human-readable error message returned by the API ("meta.msg" for the v1 API).
+	Message string
+
+	// Code is the symbolic error code returned by the v2 and UniFi OS APIs (eg: api.err.InvalidPayload).
+	Code string
+
+	// ErrorCode is the numeric error code returned by the v2 API.
+	ErrorCode int
+
+	// Details holds any additional error details returned by the v2 API.
+	Details any
+
+	// Body is the raw response body, which is only kept when no error message could be decoded from it.
+	Body string
+}
+
+// newError creates a new Error from the given response and its decoded error body.
+func newError(resp *resty.Response, body *errorResponse) *Error {
+	e := &Error{
+		Method:     resp.Request.Method,
+		Endpoint:   resp.Request.URL,
+		StatusCode: resp.StatusCode(),
+	}
+	if resp.Request.RawRequest != nil {
+		e.Endpoint = resp.Request.RawRequest.URL.Path
+	}
+	if body != nil {
+		e.ReturnCode = body.Meta.RC
+		e.Message = body.Meta.Message
+		if body.Message != "" {
+			e.Message = body.Message
+		}
+		e.Code = body.Code
+		e.ErrorCode = body.ErrorCode
+		e.Details = body.Details
+	}
+	if e.Message == "" && e.Code == "" {
+		e.Body = strings.TrimSpace(string(resp.Body()))
+	}
+	return e
+}
+
+// Error returns a string representation of the error.
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s returned HTTP %d", e.Method, e.Endpoint, e.StatusCode)
+	if e.Code != "" {
+		fmt.Fprintf(&b, " (%s)", e.Code)
+	}
+	switch {
+	case e.Message != "":
+		fmt.Fprintf(&b, ": %s", e.Message)
+	case e.Body != "":
+		fmt.Fprintf(&b, ": %s", e.Body)
+	}
+	return b.String()
+}
+
+// Is allows errors.Is to match an Error against the sentinel errors in this package.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrMFARequired:
+		return e.Code == "MFA_AUTH_REQUIRED"
+	case ErrNotFound:
+		return e.StatusCode == 404
+	}
+	return false
+}
+
+// logFields returns the error's fields for use with tflog.
+func (e *Error) logFields() map[string]any {
+	return map[string]any{
+		"method":      e.Method,
+		"endpoint":    e.Endpoint,
+		"status_code": e.StatusCode,
+		"return_code": e.ReturnCode,
+		"message":     e.Message,
+		"code":        e.Code,
+		"error_code":  e.ErrorCode,
+		"details":     e.Details,
+		"body":        e.Body,
+	}
+}
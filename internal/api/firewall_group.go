@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+)
+
+// firewallGroups is the v1 REST collection holding the address and port groups referenced by firewall rules.
+var firewallGroups = restCollection{name: "firewallgroup", objectName: "firewall group"}
+
+type FirewallGroup struct {
+	GroupMembers []string `json:"group_members"`
+	GroupType    string   `json:"group_type"`
+	ID           string   `json:"_id,omitempty"`
+	Name         string   `json:"name"`
+	SiteID       string   `json:"site_id,omitempty"`
+}
+
+func (g FirewallGroup) objectID() string {
+	return g.ID
+}
+
+func (c *Client) CreateFirewallGroup(ctx context.Context, site string, group FirewallGroup) (FirewallGroup, error) {
+	return createRESTObject(ctx, c, firewallGroups, site, group)
+}
+
+func (c *Client) DeleteFirewallGroup(ctx context.Context, site, id string) error {
+	return deleteRESTObject(ctx, c, firewallGroups, site, id)
+}
+
+func (c *Client) GetFirewallGroups(ctx context.Context, site string) ([]FirewallGroup, error) {
+	return getRESTObjects[FirewallGroup](ctx, c, firewallGroups, site)
+}
+
+func (c *Client) GetFirewallGroup(ctx context.Context, site, id string) (FirewallGroup, error) {
+	return getRESTObject[FirewallGroup](ctx, c, firewallGroups, site, id)
+}
+
+func (c *Client) UpdateFirewallGroup(ctx context.Context, site, id string, group FirewallGroup) (FirewallGroup, error) {
+	return updateRESTObject(ctx, c, firewallGroups, site, id, group)
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// firewallPolicies is the v2 collection holding the policies of the zone-based firewall.
+var firewallPolicies = v2Collection{path: "firewall-policies", objectName: "firewall policy"}
+
+type FirewallPolicy struct {
+	Action              string                 `json:"action"`
+	ConnectionStateType string                 `json:"connection_state_type"`
+	ConnectionStates    []string               `json:"connection_states"`
+	Description         string                 `json:"description"`
+	Destination         FirewallPolicyEndpoint `json:"destination"`
+	Enabled             bool                   `json:"enabled"`
+	ID                  string                 `json:"_id,omitempty"`
+	Index               int                    `json:"index,omitempty"`
+	IPVersion           string                 `json:"ip_version"`
+	Logging             bool                   `json:"logging"`
+	Name                string                 `json:"name"`
+	Predefined          bool                   `json:"predefined,omitempty"`
+	Protocol            string                 `json:"protocol"`
+	Schedule            FirewallPolicySchedule `json:"schedule"`
+	Source              FirewallPolicyEndpoint `json:"source"`
+}
+
+// FirewallPolicyEndpoint describes the traffic matched by the source or destination of a firewall policy.
+type FirewallPolicyEndpoint struct {
+	IPs              []string `json:"ips"`
+	MatchingTarget   string   `json:"matching_target"`
+	NetworkIDs       []string `json:"network_ids"`
+	Port             string   `json:"port,omitempty"`
+	PortMatchingType string   `json:"port_matching_type"`
+	ZoneID           string   `json:"zone_id"`
+}
+
+// FirewallPolicySchedule describes when a firewall policy is active.
+type FirewallPolicySchedule struct {
+	Mode           string   `json:"mode"`
+	RepeatOnDays   []string `json:"repeat_on_days"`
+	TimeAllDay     bool     `json:"time_all_day"`
+	TimeRangeEnd   string   `json:"time_range_end,omitempty"`
+	TimeRangeStart string   `json:"time_range_start,omitempty"`
+}
+
+func (p FirewallPolicy) objectID() string {
+	return p.ID
+}
+
+func (c *Client) CreateFirewallPolicy(ctx context.Context, site string, policy FirewallPolicy) (FirewallPolicy, error) {
+	return createV2Object(ctx, c, firewallPolicies, site, policy)
+}
+
+// DeleteFirewallPolicy deletes a firewall policy.
+//
+// Unlike the other v2 collections, firewall policies are deleted in batches by posting their IDs.
+func (c *Client) DeleteFirewallPolicy(ctx context.Context, site, id string) error {
+	ctx = c.addSiteContext(ctx, site)
+	ctx = tflog.SetField(ctx, "id", id)
+
+	// POST /proxy/network/v2/api/site/:site/firewall-policies/batch-delete
+	url, req := c.newAuthenticatedRequest(ctx, firewallPolicies.url(site, "batch-delete"))
+	tflog.Debug(ctx, "deleting firewall policy", map[string]any{
+		"url": url,
+	})
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetBody([]string{id}).
+		SetError(&apiResponseError).
+		Post(url)
+	c.cache.invalidate(firewallPolicies.cacheKey(site))
+	if err != nil {
+		tflog.Error(ctx, "failed to execute POST request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to delete firewall policy", apiErr.logFields())
+		return fmt.Errorf("failed to delete firewall policy: %w", apiErr)
+	}
+	return nil
+}
+
+func (c *Client) GetFirewallPolicies(ctx context.Context, site string) ([]FirewallPolicy, error) {
+	return getV2Objects[FirewallPolicy](ctx, c, firewallPolicies, site)
+}
+
+func (c *Client) GetFirewallPolicy(ctx context.Context, site, id string) (FirewallPolicy, error) {
+	return getV2Object[FirewallPolicy](ctx, c, firewallPolicies, site, id)
+}
+
+func (c *Client) UpdateFirewallPolicy(ctx context.Context, site, id string, policy FirewallPolicy) (FirewallPolicy,
+	error) {
+
+	return updateV2Object(ctx, c, firewallPolicies, site, id, policy)
+}
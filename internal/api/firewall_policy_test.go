@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestFirewallPolicyLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+
+	internal := findFirewallZone(t, c, "internal")
+	external := findFirewallZone(t, c, "external")
+	policy := FirewallPolicy{
+		Action:              "BLOCK",
+		ConnectionStateType: "ALL",
+		ConnectionStates:    []string{},
+		Destination: FirewallPolicyEndpoint{
+			IPs: []string{}, MatchingTarget: "ANY", NetworkIDs: []string{}, Port: "443", PortMatchingType: "SPECIFIC",
+			ZoneID: external.ID,
+		},
+		Enabled:   true,
+		IPVersion: "BOTH",
+		Name:      "Block internal to external",
+		Protocol:  "tcp",
+		Schedule: FirewallPolicySchedule{Mode: "EVERY_WEEK", RepeatOnDays: []string{"sat", "sun"},
+			TimeRangeStart: "08:00", TimeRangeEnd: "18:00"},
+		Source: FirewallPolicyEndpoint{
+			IPs: []string{"192.168.1.50"}, MatchingTarget: "IP", NetworkIDs: []string{}, PortMatchingType: "ANY",
+			ZoneID: internal.ID,
+		},
+	}
+	created, err := c.CreateFirewallPolicy(ctx, DefaultSite, policy)
+	if err != nil {
+		t.Fatalf("CreateFirewallPolicy() returned an error: %s", err)
+	}
+	if created.ID == "" || created.Index == 0 {
+		t.Fatalf("CreateFirewallPolicy() = %+v, want the policy with an ID and an index", created)
+	}
+
+	// the nested source, destination and schedule survive the round trip
+	got, err := c.GetFirewallPolicy(ctx, DefaultSite, created.ID)
+	if err != nil {
+		t.Fatalf("GetFirewallPolicy() returned an error: %s", err)
+	}
+	if !reflect.DeepEqual(got.Source, policy.Source) || !reflect.DeepEqual(got.Destination, policy.Destination) ||
+		!reflect.DeepEqual(got.Schedule, policy.Schedule) {
+		t.Errorf("GetFirewallPolicy() = %+v, want the source, destination and schedule of %+v", got, policy)
+	}
+
+	// policies are deleted through the batch delete endpoint
+	if err := c.DeleteFirewallPolicy(ctx, DefaultSite, created.ID); err != nil {
+		t.Fatalf("DeleteFirewallPolicy() returned an error: %s", err)
+	}
+	if _, err := c.GetFirewallPolicy(ctx, DefaultSite, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetFirewallPolicy() after delete error = %v, want %v", err, ErrNotFound)
+	}
+}
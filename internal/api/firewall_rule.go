@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"fmt"
+)
+
+// firewallRules is the v1 REST collection holding the rules of the legacy firewall rulesets.
+var firewallRules = restCollection{name: "firewallrule", objectName: "firewall rule"}
+
+type FirewallRule struct {
+	Action                string   `json:"action"`
+	DstAddress            string   `json:"dst_address"`
+	DstFirewallGroupIDs   []string `json:"dst_firewallgroup_ids"`
+	DstNetworkConfID      string   `json:"dst_networkconf_id"`
+	DstNetworkConfType    string   `json:"dst_networkconf_type"`
+	DstPort               string   `json:"dst_port"`
+	Enabled               bool     `json:"enabled"`
+	ID                    string   `json:"_id,omitempty"`
+	IPSec                 string   `json:"ipsec"`
+	Logging               bool     `json:"logging"`
+	Name                  string   `json:"name"`
+	Protocol              string   `json:"protocol"`
+	ProtocolMatchExcepted bool     `json:"protocol_match_excepted"`
+	ProtocolV6            string   `json:"protocol_v6"`
+	RuleIndex             int      `json:"rule_index"`
+	Ruleset               string   `json:"ruleset"`
+	SiteID                string   `json:"site_id,omitempty"`
+	SrcAddress            string   `json:"src_address"`
+	SrcFirewallGroupIDs   []string `json:"src_firewallgroup_ids"`
+	SrcMACAddress         string   `json:"src_mac_address"`
+	SrcNetworkConfID      string   `json:"src_networkconf_id"`
+	SrcNetworkConfType    string   `json:"src_networkconf_type"`
+	SrcPort               string   `json:"src_port"`
+	StateEstablished      bool     `json:"state_established"`
+	StateInvalid          bool     `json:"state_invalid"`
+	StateNew              bool     `json:"state_new"`
+	StateRelated          bool     `json:"state_related"`
+}
+
+func (r FirewallRule) objectID() string {
+	return r.ID
+}
+
+func (c *Client) CreateFirewallRule(ctx context.Context, site string, rule FirewallRule) (FirewallRule, error) {
+	return createRESTObject(ctx, c, firewallRules, site, rule)
+}
+
+func (c *Client) DeleteFirewallRule(ctx context.Context, site, id string) error {
+	return deleteRESTObject(ctx, c, firewallRules, site, id)
+}
+
+func (c *Client) GetFirewallRules(ctx context.Context, site string) ([]FirewallRule, error) {
+	return getRESTObjects[FirewallRule](ctx, c, firewallRules, site)
+}
+
+func (c *Client) GetFirewallRule(ctx context.Context, site, id string) (FirewallRule, error) {
+	return getRESTObject[FirewallRule](ctx, c, firewallRules, site, id)
+}
+
+// GetFirewallRuleByIndex retrieves the rule with the given index in a ruleset, returning an error wrapping ErrNotFound if
+// the index is free.
+//
+// The rules are always retrieved from the UDM rather than the cache, since the result is used to decide whether or not
+// an index can be taken.
+func (c *Client) GetFirewallRuleByIndex(ctx context.Context, site, ruleset string, index int) (FirewallRule, error) {
+	rules, err := listRESTObjects[FirewallRule](c.addSiteContext(ctx, site), c, firewallRules, site)
+	if err != nil {
+		return FirewallRule{}, err
+	}
+	for _, rule := range rules {
+		if rule.Ruleset == ruleset && rule.RuleIndex == index {
+			return rule, nil
+		}
+	}
+	return FirewallRule{}, fmt.Errorf("%w: no firewall rule found with an index of %d in the %s ruleset", ErrNotFound,
+		index, ruleset)
+}
+
+func (c *Client) UpdateFirewallRule(ctx context.Context, site, id string, rule FirewallRule) (FirewallRule, error) {
+	return updateRESTObject(ctx, c, firewallRules, site, id, rule)
+}
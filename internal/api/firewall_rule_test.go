@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestGetFirewallRuleByIndex(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+
+	created, err := c.CreateFirewallRule(ctx, DefaultSite, FirewallRule{Action: "drop", DstFirewallGroupIDs: []string{},
+		Enabled: true, Name: "Block IoT to LAN", Protocol: "all", RuleIndex: 2000, Ruleset: "LAN_IN",
+		SrcFirewallGroupIDs: []string{}})
+	if err != nil {
+		t.Fatalf("CreateFirewallRule() returned an error: %s", err)
+	}
+	got, err := c.GetFirewallRuleByIndex(ctx, DefaultSite, "LAN_IN", 2000)
+	if err != nil {
+		t.Fatalf("GetFirewallRuleByIndex() returned an error: %s", err)
+	}
+	if got.ID != created.ID {
+		t.Errorf("GetFirewallRuleByIndex() ID = %q, want %q", got.ID, created.ID)
+	}
+	if _, err := c.GetFirewallRuleByIndex(ctx, DefaultSite, "LAN_LOCAL", 2000); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetFirewallRuleByIndex() of a free index error = %v, want %v", err, ErrNotFound)
+	}
+
+	// rules added since the collection was cached are still found
+	if _, err := c.GetFirewallRules(ctx, DefaultSite); err != nil {
+		t.Fatalf("GetFirewallRules() returned an error: %s", err)
+	}
+	id := server.AddObject(udmmock.DefaultSite, udmmock.CollectionFirewallRules, map[string]any{
+		"action":      "accept",
+		"name":        "Allow DNS",
+		"protocol_v6": "udp",
+		"rule_index":  float64(4000),
+		"ruleset":     "LANv6_LOCAL",
+	})
+	got, err = c.GetFirewallRuleByIndex(ctx, DefaultSite, "LANv6_LOCAL", 4000)
+	if err != nil {
+		t.Fatalf("GetFirewallRuleByIndex() of a rule added outside of the client returned an error: %s", err)
+	}
+	if got.ID != id || got.ProtocolV6 != "udp" {
+		t.Errorf("GetFirewallRuleByIndex() = %+v, want the rule with the ID %s and an IPv6 protocol of udp", got, id)
+	}
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+)
+
+// firewallZones is the v2 collection holding the zones of the zone-based firewall.
+var firewallZones = v2Collection{path: "firewall/zone", objectName: "firewall zone"}
+
+type FirewallZone struct {
+	DefaultZone bool     `json:"default_zone,omitempty"`
+	ID          string   `json:"_id,omitempty"`
+	Name        string   `json:"name"`
+	NetworkIDs  []string `json:"network_ids"`
+	ZoneKey     string   `json:"zone_key,omitempty"`
+}
+
+func (z FirewallZone) objectID() string {
+	return z.ID
+}
+
+func (c *Client) CreateFirewallZone(ctx context.Context, site string, zone FirewallZone) (FirewallZone, error) {
+	return createV2Object(ctx, c, firewallZones, site, zone)
+}
+
+func (c *Client) DeleteFirewallZone(ctx context.Context, site, id string) error {
+	return deleteV2Object(ctx, c, firewallZones, site, id)
+}
+
+func (c *Client) GetFirewallZones(ctx context.Context, site string) ([]FirewallZone, error) {
+	return getV2Objects[FirewallZone](ctx, c, firewallZones, site)
+}
+
+func (c *Client) GetFirewallZone(ctx context.Context, site, id string) (FirewallZone, error) {
+	return getV2Object[FirewallZone](ctx, c, firewallZones, site, id)
+}
+
+func (c *Client) UpdateFirewallZone(ctx context.Context, site, id string, zone FirewallZone) (FirewallZone, error) {
+	return updateV2Object(ctx, c, firewallZones, site, id, zone)
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+// findFirewallZone returns the zone with the given zone key or name, failing the test if there is none.
+func findFirewallZone(t *testing.T, c *Client, keyOrName string) FirewallZone {
+	t.Helper()
+	zones, err := c.GetFirewallZones(context.Background(), DefaultSite)
+	if err != nil {
+		t.Fatalf("GetFirewallZones() returned an error: %s", err)
+	}
+	for _, zone := range zones {
+		if zone.ZoneKey == keyOrName || zone.Name == keyOrName {
+			return zone
+		}
+	}
+	t.Fatalf("GetFirewallZones() = %+v, want a zone named %s", zones, keyOrName)
+	return FirewallZone{}
+}
+
+func TestGetFirewallZonesBuiltIn(t *testing.T) {
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+
+	// the built-in zones are identified by their zone key rather than their name
+	for _, key := range []string{"internal", "external", "gateway"} {
+		zone := findFirewallZone(t, c, key)
+		if !zone.DefaultZone || zone.ID == "" {
+			t.Errorf("zone %s = %+v, want a built-in zone with an ID", key, zone)
+		}
+	}
+}
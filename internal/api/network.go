@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+)
+
+// networks is the v1 REST collection holding networks.
+var networks = restCollection{name: "networkconf", objectName: "network"}
+
+type Network struct {
+	DHCPDNS1                string `json:"dhcpd_dns_1"`
+	DHCPDNS2                string `json:"dhcpd_dns_2"`
+	DHCPDNS3                string `json:"dhcpd_dns_3"`
+	DHCPDNS4                string `json:"dhcpd_dns_4"`
+	DHCPDNSEnabled          bool   `json:"dhcpd_dns_enabled"`
+	DHCPEnabled             bool   `json:"dhcpd_enabled"`
+	DHCPLeaseTime           int    `json:"dhcpd_leasetime,omitempty"`
+	DHCPStart               string `json:"dhcpd_start,omitempty"`
+	DHCPStop                string `json:"dhcpd_stop,omitempty"`
+	DomainName              string `json:"domain_name"`
+	Enabled                 bool   `json:"enabled"`
+	ID                      string `json:"_id,omitempty"`
+	IGMPSnooping            bool   `json:"igmp_snooping"`
+	IPSubnet                string `json:"ip_subnet,omitempty"`
+	IPv6InterfaceType       string `json:"ipv6_interface_type,omitempty"`
+	Name                    string `json:"name"`
+	NetworkGroup            string `json:"networkgroup,omitempty"`
+	NetworkIsolationEnabled bool   `json:"network_isolation_enabled"`
+	Purpose                 string `json:"purpose"`
+	SiteID                  string `json:"site_id,omitempty"`
+	VLAN                    int    `json:"vlan,omitempty"`
+	VLANEnabled             bool   `json:"vlan_enabled"`
+}
+
+// DHCPDNSServers returns the DNS servers handed out by the network's DHCP server, if any.
+func (n Network) DHCPDNSServers() []string {
+	servers := []string{}
+	for _, server := range []string{n.DHCPDNS1, n.DHCPDNS2, n.DHCPDNS3, n.DHCPDNS4} {
+		if server != "" {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// SetDHCPDNSServers sets the DNS servers handed out by the network's DHCP server.  Only the first 4 servers are used
+// and the DHCP server hands out the gateway itself if there are none.
+func (n *Network) SetDHCPDNSServers(servers []string) {
+	slots := []*string{&n.DHCPDNS1, &n.DHCPDNS2, &n.DHCPDNS3, &n.DHCPDNS4}
+	for i, slot := range slots {
+		*slot = ""
+		if i < len(servers) {
+			*slot = servers[i]
+		}
+	}
+	n.DHCPDNSEnabled = len(servers) > 0
+}
+
+func (n Network) objectID() string {
+	return n.ID
+}
+
+func (c *Client) CreateNetwork(ctx context.Context, site string, network Network) (Network, error) {
+	return createRESTObject(ctx, c, networks, site, network)
+}
+
+func (c *Client) DeleteNetwork(ctx context.Context, site, id string) error {
+	return deleteRESTObject(ctx, c, networks, site, id)
+}
+
+func (c *Client) GetNetworks(ctx context.Context, site string) ([]Network, error) {
+	return getRESTObjects[Network](ctx, c, networks, site)
+}
+
+func (c *Client) GetNetwork(ctx context.Context, site, id string) (Network, error) {
+	return getRESTObject[Network](ctx, c, networks, site, id)
+}
+
+func (c *Client) UpdateNetwork(ctx context.Context, site, id string, network Network) (Network, error) {
+	return updateRESTObject(ctx, c, networks, site, id, network)
+}
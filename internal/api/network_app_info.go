@@ -7,8 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-type getNetworkAppInfoResponseError struct{}
-
 type getNetworkAppInfoResponseSuccess struct {
 	NetworkAppInfo
 }
@@ -48,7 +46,7 @@ func (c *Client) GetNetworkAppInfo(ctx context.Context) (NetworkAppInfo, error)
 		"url": url,
 	})
 	apiResponseSuccess := getNetworkAppInfoResponseSuccess{}
-	apiResponseError := getNetworkAppInfoResponseError{}
+	apiResponseError := errorResponse{}
 	resp, err := req.
 		SetResult(&apiResponseSuccess).
 		SetError(&apiResponseError).
@@ -63,10 +61,9 @@ func (c *Client) GetNetworkAppInfo(ctx context.Context) (NetworkAppInfo, error)
 
 	// if a non-200 error was returned by the API, something went wrong
 	if statusCode != 200 {
-		tflog.Error(ctx, "failed to retrieve network info", map[string]any{
-			"body": resp.Body(),
-		})
-		return NetworkAppInfo{}, fmt.Errorf("failed to retrieve network info")
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to retrieve network info", apiErr.logFields())
+		return NetworkAppInfo{}, fmt.Errorf("failed to retrieve network info: %w", apiErr)
 	}
 
 	return apiResponseSuccess.NetworkAppInfo, nil
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNetworkDHCPDNSServers(t *testing.T) {
+	tests := []struct {
+		servers []string
+		want    []string
+	}{
+		{nil, nil},
+		{[]string{"1.1.1.1", "9.9.9.9"}, []string{"1.1.1.1", "9.9.9.9"}},
+		{[]string{"1.1.1.1", "1.0.0.1", "9.9.9.9", "149.112.112.112", "8.8.8.8"},
+			[]string{"1.1.1.1", "1.0.0.1", "9.9.9.9", "149.112.112.112"}},
+	}
+	for _, tt := range tests {
+		// servers left over from a previous setting are cleared
+		network := Network{DHCPDNS1: "8.8.8.8", DHCPDNS2: "8.8.4.4", DHCPDNS3: "8.8.8.8", DHCPDNS4: "8.8.4.4"}
+		network.SetDHCPDNSServers(tt.servers)
+		if got := network.DHCPDNSServers(); !slices.Equal(got, tt.want) {
+			t.Errorf("DHCPDNSServers() after SetDHCPDNSServers(%v) = %v, want %v", tt.servers, got, tt.want)
+		}
+		if network.DHCPDNSEnabled != (len(tt.want) > 0) {
+			t.Errorf("DHCPDNSEnabled after SetDHCPDNSServers(%v) = %t, want %t", tt.servers, network.DHCPDNSEnabled,
+				len(tt.want) > 0)
+		}
+	}
+}
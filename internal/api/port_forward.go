@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+)
+
+// portForwards is the v1 REST collection holding the port forwarding rules of the WAN interfaces.
+var portForwards = restCollection{name: "portforward", objectName: "port forward"}
+
+type PortForward struct {
+	DstPort            string `json:"dst_port"`
+	Enabled            bool   `json:"enabled"`
+	Fwd                string `json:"fwd"`
+	FwdPort            string `json:"fwd_port"`
+	ID                 string `json:"_id,omitempty"`
+	Log                bool   `json:"log"`
+	Name               string `json:"name"`
+	PortForwardIface   string `json:"pfwd_interface"`
+	Protocol           string `json:"proto"`
+	SiteID             string `json:"site_id,omitempty"`
+	Src                string `json:"src"`
+	SrcFirewallGroupID string `json:"src_firewall_group_id,omitempty"`
+	SrcLimitingEnabled bool   `json:"src_limiting_enabled"`
+	SrcLimitingType    string `json:"src_limiting_type,omitempty"`
+}
+
+func (p PortForward) objectID() string {
+	return p.ID
+}
+
+func (c *Client) CreatePortForward(ctx context.Context, site string, portForward PortForward) (PortForward, error) {
+	return createRESTObject(ctx, c, portForwards, site, portForward)
+}
+
+func (c *Client) DeletePortForward(ctx context.Context, site, id string) error {
+	return deleteRESTObject(ctx, c, portForwards, site, id)
+}
+
+func (c *Client) GetPortForwards(ctx context.Context, site string) ([]PortForward, error) {
+	return getRESTObjects[PortForward](ctx, c, portForwards, site)
+}
+
+func (c *Client) GetPortForward(ctx context.Context, site, id string) (PortForward, error) {
+	return getRESTObject[PortForward](ctx, c, portForwards, site, id)
+}
+
+func (c *Client) UpdatePortForward(ctx context.Context, site, id string, portForward PortForward) (PortForward,
+	error) {
+
+	return updateRESTObject(ctx, c, portForwards, site, id, portForward)
+}
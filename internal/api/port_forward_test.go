@@ -0,0 +1,38 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestPortForwardFieldMapping(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+
+	created, err := c.CreatePortForward(ctx, DefaultSite, PortForward{
+		DstPort:          "8443",
+		Enabled:          true,
+		Fwd:              "192.168.1.20",
+		FwdPort:          "443",
+		Name:             "NAS",
+		PortForwardIface: "wan",
+		Protocol:         "tcp",
+		Src:              "any",
+	})
+	if err != nil {
+		t.Fatalf("CreatePortForward() returned an error: %s", err)
+	}
+
+	// the interface and protocol are stored in fields named differently from their attributes and no firewall group
+	// is sent for port forwards which are not limited to one
+	object, _ := server.Object(udmmock.DefaultSite, udmmock.CollectionPortForwards, created.ID)
+	if object["pfwd_interface"] != "wan" || object["proto"] != "tcp" {
+		t.Errorf("pfwd_interface = %v, proto = %v, want wan and tcp", object["pfwd_interface"], object["proto"])
+	}
+	if _, ok := object["src_firewall_group_id"]; ok {
+		t.Errorf("src_firewall_group_id = %v, want no firewall group", object["src_firewall_group_id"])
+	}
+}
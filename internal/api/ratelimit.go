@@ -0,0 +1,84 @@
+package api
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	DefaultMaxConcurrentRequests = 4  // Default maximum number of requests in flight at once
+	DefaultMaxRequestsPerSecond  = 10 // Default maximum number of requests sent per second
+)
+
+// limitedTransport is an http.RoundTripper which throttles requests with a token bucket and caps the number of
+// requests in flight.
+//
+// A request holds its slot until its response body is closed so the cap covers the whole exchange.  Waiting for a
+// token or a slot is aborted when the request's context is done.
+type limitedTransport struct {
+	limiter   *rate.Limiter // nil if the request rate is unlimited
+	next      http.RoundTripper
+	semaphore chan struct{} // nil if the number of concurrent requests is unlimited
+}
+
+// newLimitedTransport wraps next with a limiter allowing requestsPerSecond requests per second and at most
+// maxConcurrent requests in flight.  A limit of 0 disables it.
+func newLimitedTransport(next http.RoundTripper, requestsPerSecond float64, maxConcurrent int) *limitedTransport {
+	t := &limitedTransport{
+		next: next,
+	}
+	if requestsPerSecond > 0 {
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), max(1, int(math.Ceil(requestsPerSecond))))
+	}
+	if maxConcurrent > 0 {
+		t.semaphore = make(chan struct{}, maxConcurrent)
+	}
+	return t
+}
+
+// RoundTrip executes a single HTTP transaction once a token and a slot are available.
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	release := func() {}
+	if t.semaphore != nil {
+		select {
+		case t.semaphore <- struct{}{}:
+			release = sync.OnceFunc(func() { <-t.semaphore })
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releasingReadCloser{
+		ReadCloser: resp.Body,
+		release:    release,
+	}
+	return resp, nil
+}
+
+// releasingReadCloser releases a limitedTransport slot when the response body is closed.
+type releasingReadCloser struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close closes the response body and releases the slot.
+func (r *releasingReadCloser) Close() error {
+	defer r.release()
+	return r.ReadCloser.Close()
+}
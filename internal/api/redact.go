@@ -0,0 +1,91 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	redactedValue = "REDACTED" // Value which replaces secrets in logs and recorded interactions
+)
+
+var (
+	// sensitiveHeaders are the request and response headers whose values are secrets.
+	sensitiveHeaders = []string{APIKeyHeaderName, "Authorization", "Cookie", "Set-Cookie", "X-Csrf-Token",
+		"X-Updated-Csrf-Token"}
+
+	// sensitiveJSONFields are the fields of JSON bodies whose values are secrets.  The network application also
+	// prefixes the name of every secret field of its objects (eg: x_passphrase, x_password) with "x_", so those are
+	// always considered sensitive as well.
+	sensitiveJSONFields = []string{"apiKey", "csrfToken", "deviceToken", "passphrase", "password", "private_key",
+		"psk", "radius_secret", "secret", "sso_token", "token", "totp_secret", "wpa_psk"}
+)
+
+// isSensitiveJSONField returns whether or not the value of the given JSON field is a secret.
+func isSensitiveJSONField(key string) bool {
+	return strings.HasPrefix(key, "x_") || slices.Contains(sensitiveJSONFields, key)
+}
+
+// redactHeaders returns a copy of the given headers with the values of sensitive headers replaced.
+func redactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range sensitiveHeaders {
+		if values := redacted.Values(name); len(values) > 0 {
+			redacted[http.CanonicalHeaderKey(name)] = slices.Repeat([]string{redactedValue}, len(values))
+		}
+	}
+	return redacted
+}
+
+// redactJSON replaces the non-empty values of the fields for which sensitive returns true anywhere in a JSON
+// document.  The document is returned as is if it cannot be parsed.
+func redactJSON(data []byte, sensitive func(key string) bool) []byte {
+	// numbers are kept as is so large IDs and timestamps survive the round trip
+	var doc any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return data
+	}
+	redacted, err := json.Marshal(redactJSONValue(doc, sensitive))
+	if err != nil {
+		return data
+	}
+	return redacted
+}
+
+// redactJSONValue replaces the values of sensitive fields in a decoded JSON value in place and returns it.
+func redactJSONValue(v any, sensitive func(key string) bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if sensitive(key) && value != nil && value != "" {
+				v[key] = redactedValue
+				continue
+			}
+			redactJSONValue(value, sensitive)
+		}
+	case []any:
+		for _, value := range v {
+			redactJSONValue(value, sensitive)
+		}
+	}
+	return v
+}
+
+// redactObject returns the JSON representation of an API object with the values of sensitive fields replaced, ready
+// to be added to a log entry.  The object is returned as is if it cannot be encoded.
+func redactObject(object any) any {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return object
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return object
+	}
+	return redactJSONValue(doc, isSensitiveJSONField)
+}
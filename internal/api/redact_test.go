@@ -0,0 +1,110 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "top-level fields",
+			data: `{"deviceToken":"abc","username":"admin"}`,
+			want: `{"deviceToken":"REDACTED","username":"admin"}`,
+		},
+		{
+			name: "nested fields",
+			data: `{"data":[{"name":"guest","x_passphrase":"hunter2","wpa":{"psk":"hunter2"}}],"meta":{"rc":"ok"}}`,
+			want: `{"data":[{"name":"guest","wpa":{"psk":"REDACTED"},"x_passphrase":"REDACTED"}],"meta":{"rc":"ok"}}`,
+		},
+		{
+			name: "empty secrets",
+			data: `{"password":"","x_password":null}`,
+			want: `{"password":"","x_password":null}`,
+		},
+		{
+			name: "large numbers",
+			data: `{"id":12345678901234567890,"token":"abc"}`,
+			want: `{"id":12345678901234567890,"token":"REDACTED"}`,
+		},
+		{
+			name: "not JSON",
+			data: `password=hunter2`,
+			want: `password=hunter2`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(redactJSON([]byte(tt.data), isSensitiveJSONField)); got != tt.want {
+				t.Errorf("redactJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("X-Csrf-Token", "abc")
+	header.Add("Set-Cookie", "TOKEN=abc")
+	header.Add("Set-Cookie", "other=def")
+
+	got := redactHeaders(header)
+	if got.Get("Content-Type") != "application/json" {
+		t.Errorf("Content-Type = %q, want it unchanged", got.Get("Content-Type"))
+	}
+	if got.Get("X-Csrf-Token") != redactedValue {
+		t.Errorf("X-Csrf-Token = %q, want %q", got.Get("X-Csrf-Token"), redactedValue)
+	}
+	if cookies := got.Values("Set-Cookie"); len(cookies) != 2 || cookies[0] != redactedValue {
+		t.Errorf("Set-Cookie = %q, want two redacted values", cookies)
+	}
+	if header.Get("X-Csrf-Token") != "abc" {
+		t.Error("redactHeaders() modified the original headers")
+	}
+}
+
+func TestLoggingRedactsSecrets(t *testing.T) {
+	t.Setenv("TF_LOG", "DEBUG")
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	const password = "correct-horse-battery-staple"
+	server := newTestServer(t, udmmock.Config{Password: password})
+	c := newTestClient(t, server)
+	if err := c.Login(ctx, Credentials{Username: udmmock.DefaultUsername, Password: password}); err != nil {
+		t.Fatalf("Login() returned an error: %s", err)
+	}
+	if _, err := c.GetStaticDNSRecords(ctx, DefaultSite); err != nil {
+		t.Fatalf("GetStaticDNSRecords() returned an error: %s", err)
+	}
+
+	logs := output.String()
+	if !strings.Contains(logs, "response received from") {
+		t.Fatal("no responses were logged")
+	}
+	session := c.session()
+	for name, secret := range map[string]string{
+		"password":   password,
+		"JWT":        session.JWT,
+		"CSRF token": session.CSRFToken,
+	} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("logs contain the %s", name)
+		}
+	}
+	if !strings.Contains(logs, `\"deviceToken\":\"REDACTED\"`) {
+		t.Error("logs do not contain the redacted device token")
+	}
+}
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// restResponseSuccess is the body returned by the v1 REST API (/proxy/network/api/s/:site/rest/:collection).
+type restResponseSuccess[T any] struct {
+	Meta struct {
+		RC      string `json:"rc"`
+		Message string `json:"msg"`
+	} `json:"meta"`
+	Data []T `json:"data"`
+}
+
+// restObject is implemented by the objects stored in v1 REST and v2 collections.
+type restObject interface {
+	// objectID returns the ID assigned to the object by the UDM.
+	objectID() string
+}
+
+// restCollection describes a collection of the v1 REST API.
+type restCollection struct {
+	// name of the collection in the URL (eg: networkconf)
+	name string
+
+	// human-readable name of a single object in the collection, used in log and error messages (eg: network)
+	objectName string
+}
+
+// cacheKey returns the key of the collection within a site in the collection cache.
+func (rc restCollection) cacheKey(site string) string {
+	return collectionCacheKey(site, "rest/"+rc.name)
+}
+
+// url returns the path of the collection within a site or of a single object if an ID is given.
+func (rc restCollection) url(site, id string) string {
+	if id == "" {
+		return fmt.Sprintf("/proxy/network/api/s/%s/rest/%s", site, rc.name)
+	}
+	return fmt.Sprintf("/proxy/network/api/s/%s/rest/%s/%s", site, rc.name, id)
+}
+
+// createRESTObject creates an object in a v1 REST collection and returns the object created by the UDM.
+func createRESTObject[T any](ctx context.Context, c *Client, rc restCollection, site string, object T) (T, error) {
+	var empty T
+	ctx = c.addSiteContext(ctx, site)
+
+	// POST /proxy/network/api/s/:site/rest/:collection
+	url, req := c.newAuthenticatedRequest(ctx, rc.url(site, ""))
+	tflog.Debug(ctx, fmt.Sprintf("creating %s", rc.objectName), map[string]any{
+		"url":    url,
+		"object": redactObject(object),
+	})
+	apiResponseSuccess := restResponseSuccess[T]{}
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetBody(object).
+		SetResult(&apiResponseSuccess).
+		SetError(&apiResponseError).
+		Post(url)
+	c.cache.invalidate(rc.cacheKey(site))
+	if err != nil {
+		tflog.Error(ctx, "failed to execute POST request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return empty, err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, fmt.Sprintf("failed to create %s", rc.objectName), apiErr.logFields())
+		return empty, fmt.Errorf("failed to create %s: %w", rc.objectName, apiErr)
+	}
+	if len(apiResponseSuccess.Data) == 0 {
+		tflog.Error(ctx, fmt.Sprintf("no %s was returned by the API", rc.objectName))
+		return empty, fmt.Errorf("failed to create %s: no %s was returned by the API", rc.objectName, rc.objectName)
+	}
+	return apiResponseSuccess.Data[0], nil
+}
+
+// deleteRESTObject deletes the object with the given ID from a v1 REST collection.
+func deleteRESTObject(ctx context.Context, c *Client, rc restCollection, site, id string) error {
+	ctx = c.addSiteContext(ctx, site)
+	ctx = tflog.SetField(ctx, "id", id)
+
+	// DELETE /proxy/network/api/s/:site/rest/:collection/:id
+	url, req := c.newAuthenticatedRequest(ctx, rc.url(site, id))
+	tflog.Debug(ctx, fmt.Sprintf("deleting %s", rc.objectName), map[string]any{
+		"url": url,
+	})
+	apiResponseSuccess := restResponseSuccess[any]{}
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetResult(&apiResponseSuccess).
+		SetError(&apiResponseError).
+		Delete(url)
+	c.cache.invalidate(rc.cacheKey(site))
+	if err != nil {
+		tflog.Error(ctx, "failed to execute DELETE request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, fmt.Sprintf("failed to delete %s", rc.objectName), apiErr.logFields())
+		return fmt.Errorf("failed to delete %s: %w", rc.objectName, apiErr)
+	}
+	return nil
+}
+
+// getRESTObjects retrieves all of the objects in a v1 REST collection, using the cached collection if there is one.
+func getRESTObjects[T any](ctx context.Context, c *Client, rc restCollection, site string) ([]T, error) {
+	ctx = c.addSiteContext(ctx, site)
+	return cachedCollection(ctx, c, rc.cacheKey(site), func() ([]T, error) {
+		return listRESTObjects[T](ctx, c, rc, site)
+	})
+}
+
+// getRESTObject retrieves the object with the given ID from a v1 REST collection.
+func getRESTObject[T restObject](ctx context.Context, c *Client, rc restCollection, site, id string) (T, error) {
+	var empty T
+	ctx = tflog.SetField(ctx, "id", id)
+	objects, err := getRESTObjects[T](ctx, c, rc, site)
+	if err != nil {
+		return empty, err
+	}
+	ctx = c.addSiteContext(ctx, site)
+
+	// find the ID in question
+	tflog.Debug(ctx, fmt.Sprintf("searching for %s", rc.objectName))
+	for _, object := range objects {
+		if object.objectID() == id {
+			tflog.Debug(ctx, fmt.Sprintf("%s was located", rc.objectName), map[string]any{
+				"object": redactObject(object),
+			})
+			return object, nil
+		}
+	}
+	tflog.Warn(ctx, fmt.Sprintf("%s not found", rc.objectName))
+	return empty, fmt.Errorf("%w: no %s found with an ID of '%s'", ErrNotFound, rc.objectName, id)
+}
+
+// listRESTObjects retrieves all of the objects in a v1 REST collection from the UDM, bypassing the cache.
+func listRESTObjects[T any](ctx context.Context, c *Client, rc restCollection, site string) ([]T, error) {
+	// GET /proxy/network/api/s/:site/rest/:collection
+	url, req := c.newAuthenticatedRequest(ctx, rc.url(site, ""))
+	tflog.Debug(ctx, fmt.Sprintf("retrieving %s objects", rc.objectName), map[string]any{
+		"url": url,
+	})
+	apiResponseSuccess := restResponseSuccess[T]{}
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetResult(&apiResponseSuccess).
+		SetError(&apiResponseError).
+		Get(url)
+	if err != nil {
+		tflog.Error(ctx, "failed to execute GET request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return nil, err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, fmt.Sprintf("failed to retrieve %s objects", rc.objectName), apiErr.logFields())
+		return nil, fmt.Errorf("failed to retrieve %s objects: %w", rc.objectName, apiErr)
+	}
+	return apiResponseSuccess.Data, nil
+}
+
+// updateRESTObject updates the object with the given ID in a v1 REST collection and returns the object updated by the
+// UDM.
+func updateRESTObject[T any](ctx context.Context, c *Client, rc restCollection, site, id string, object T) (T, error) {
+	var empty T
+	ctx = c.addSiteContext(ctx, site)
+	ctx = tflog.SetField(ctx, "id", id)
+
+	// PUT /proxy/network/api/s/:site/rest/:collection/:id
+	url, req := c.newAuthenticatedRequest(ctx, rc.url(site, id))
+	tflog.Debug(ctx, fmt.Sprintf("updating %s", rc.objectName), map[string]any{
+		"url":    url,
+		"object": redactObject(object),
+	})
+	apiResponseSuccess := restResponseSuccess[T]{}
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetBody(object).
+		SetResult(&apiResponseSuccess).
+		SetError(&apiResponseError).
+		Put(url)
+	c.cache.invalidate(rc.cacheKey(site))
+	if err != nil {
+		tflog.Error(ctx, "failed to execute PUT request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return empty, err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, fmt.Sprintf("failed to update %s", rc.objectName), apiErr.logFields())
+		return empty, fmt.Errorf("failed to update %s: %w", rc.objectName, apiErr)
+	}
+	if len(apiResponseSuccess.Data) == 0 {
+		tflog.Error(ctx, fmt.Sprintf("no %s was returned by the API", rc.objectName))
+		return empty, fmt.Errorf("failed to update %s: no %s was returned by the API", rc.objectName, rc.objectName)
+	}
+	return apiResponseSuccess.Data[0], nil
+}
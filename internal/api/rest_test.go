@@ -0,0 +1,175 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+// collectionTestObject is a minimal object used to exercise the generic collection helpers.
+type collectionTestObject struct {
+	ID   string `json:"_id,omitempty"`
+	Name string `json:"name"`
+}
+
+func (o collectionTestObject) objectID() string {
+	return o.ID
+}
+
+// collectionHelpers binds the generic helpers of one kind of collection to a collection so that every kind can be
+// tested alike.
+type collectionHelpers struct {
+	name   string
+	path   string
+	create func(ctx context.Context, c *Client, site string, object collectionTestObject) (collectionTestObject, error)
+	delete func(ctx context.Context, c *Client, site, id string) error
+	get    func(ctx context.Context, c *Client, site, id string) (collectionTestObject, error)
+	list   func(ctx context.Context, c *Client, site string) ([]collectionTestObject, error)
+	update func(ctx context.Context, c *Client, site, id string, object collectionTestObject) (collectionTestObject,
+		error)
+}
+
+// testRESTCollection is a v1 REST collection which the mock UDM stores without any validation.
+var testRESTCollection = restCollection{name: "testobject", objectName: "test object"}
+
+// testV2Collection is a v2 collection served by the mock UDM whose objects have an ID and a name.
+var testV2Collection = v2Collection{path: udmmock.CollectionFirewallZones, objectName: "test object"}
+
+// collectionHelperTests lists the helpers of each kind of collection.
+var collectionHelperTests = []collectionHelpers{
+	{
+		name: "v1 REST",
+		path: testRESTCollection.url(DefaultSite, ""),
+		create: func(ctx context.Context, c *Client, site string, object collectionTestObject) (collectionTestObject,
+			error) {
+			return createRESTObject(ctx, c, testRESTCollection, site, object)
+		},
+		delete: func(ctx context.Context, c *Client, site, id string) error {
+			return deleteRESTObject(ctx, c, testRESTCollection, site, id)
+		},
+		get: func(ctx context.Context, c *Client, site, id string) (collectionTestObject, error) {
+			return getRESTObject[collectionTestObject](ctx, c, testRESTCollection, site, id)
+		},
+		list: func(ctx context.Context, c *Client, site string) ([]collectionTestObject, error) {
+			return getRESTObjects[collectionTestObject](ctx, c, testRESTCollection, site)
+		},
+		update: func(ctx context.Context, c *Client, site, id string, object collectionTestObject) (
+			collectionTestObject, error) {
+			return updateRESTObject(ctx, c, testRESTCollection, site, id, object)
+		},
+	},
+	{
+		name: "v2",
+		path: testV2Collection.url(DefaultSite, ""),
+		create: func(ctx context.Context, c *Client, site string, object collectionTestObject) (collectionTestObject,
+			error) {
+			return createV2Object(ctx, c, testV2Collection, site, object)
+		},
+		delete: func(ctx context.Context, c *Client, site, id string) error {
+			return deleteV2Object(ctx, c, testV2Collection, site, id)
+		},
+		get: func(ctx context.Context, c *Client, site, id string) (collectionTestObject, error) {
+			return getV2Object[collectionTestObject](ctx, c, testV2Collection, site, id)
+		},
+		list: func(ctx context.Context, c *Client, site string) ([]collectionTestObject, error) {
+			return getV2Objects[collectionTestObject](ctx, c, testV2Collection, site)
+		},
+		update: func(ctx context.Context, c *Client, site, id string, object collectionTestObject) (
+			collectionTestObject, error) {
+			return updateV2Object(ctx, c, testV2Collection, site, id, object)
+		},
+	},
+}
+
+func TestCollectionHelpers(t *testing.T) {
+	for _, tt := range collectionHelperTests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			server := newTestServer(t, udmmock.Config{})
+			c := newLoggedInClient(t, server)
+
+			created, err := tt.create(ctx, c, DefaultSite, collectionTestObject{Name: "first"})
+			if err != nil {
+				t.Fatalf("create returned an error: %s", err)
+			}
+			if created.ID == "" || created.Name != "first" {
+				t.Fatalf("create = %+v, want the object with an ID", created)
+			}
+
+			// the collection is only retrieved once until it changes
+			for range 2 {
+				objects, err := tt.list(ctx, c, DefaultSite)
+				if err != nil {
+					t.Fatalf("list returned an error: %s", err)
+				}
+				found := false
+				for _, object := range objects {
+					found = found || object == created
+				}
+				if !found {
+					t.Errorf("list = %+v, want %+v", objects, created)
+				}
+			}
+			if got := server.RequestCount(http.MethodGet, tt.path); got != 1 {
+				t.Errorf("list requests = %d, want 1", got)
+			}
+
+			update := created
+			update.Name = "second"
+			updated, err := tt.update(ctx, c, DefaultSite, created.ID, update)
+			if err != nil {
+				t.Fatalf("update returned an error: %s", err)
+			}
+			if updated != update {
+				t.Errorf("update = %+v, want %+v", updated, update)
+			}
+			if got, err := tt.get(ctx, c, DefaultSite, created.ID); err != nil || got != update {
+				t.Errorf("get after update = %+v, %v, want %+v", got, err, update)
+			}
+
+			if err := tt.delete(ctx, c, DefaultSite, created.ID); err != nil {
+				t.Fatalf("delete returned an error: %s", err)
+			}
+			if _, err := tt.get(ctx, c, DefaultSite, created.ID); !errors.Is(err, ErrNotFound) {
+				t.Errorf("get after delete error = %v, want %v", err, ErrNotFound)
+			}
+			if err := tt.delete(ctx, c, DefaultSite, created.ID); err == nil {
+				t.Error("delete of a deleted object returned no error")
+			}
+
+			// failures are returned as an *Error
+			server.FailRequests(http.MethodPost, tt.path, http.StatusBadRequest, 1)
+			_, err = tt.create(ctx, c, DefaultSite, collectionTestObject{Name: "third"})
+			var apiErr *Error
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+				t.Errorf("create error = %v, want an *Error with HTTP 400", err)
+			}
+			if _, err := tt.create(ctx, c, "missing", collectionTestObject{Name: "third"}); err == nil {
+				t.Error("create in a missing site returned no error")
+			}
+		})
+	}
+}
+
+func TestRESTObjectEmptyResponse(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+	created, err := createRESTObject(ctx, c, testRESTCollection, DefaultSite, collectionTestObject{Name: "first"})
+	if err != nil {
+		t.Fatalf("createRESTObject() returned an error: %s", err)
+	}
+
+	// a successful response without any object must be reported rather than indexed
+	server.FailRequests(http.MethodPost, testRESTCollection.url(DefaultSite, ""), http.StatusOK, 1)
+	if _, err := createRESTObject(ctx, c, testRESTCollection, DefaultSite, created); err == nil {
+		t.Error("createRESTObject() with an empty response did not return an error")
+	}
+	server.FailRequests(http.MethodPut, testRESTCollection.url(DefaultSite, created.ID), http.StatusOK, 1)
+	if _, err := updateRESTObject(ctx, c, testRESTCollection, DefaultSite, created.ID, created); err == nil {
+		t.Error("updateRESTObject() with an empty response did not return an error")
+	}
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	DefaultRetryMaxAttempts = 5                // Default number of attempts made for a request
+	DefaultRetryMaxElapsed  = 5 * time.Minute  // Default time after which a failing request is no longer retried
+	DefaultRetryMaxWait     = 30 * time.Second // Default maximum time to wait between attempts
+	DefaultRetryMinWait     = 1 * time.Second  // Default time to wait before the first retry
+)
+
+// RetryPolicy controls how requests which fail with a transient error are retried.
+//
+// The wait between attempts grows exponentially from MinWait up to MaxWait with random jitter added.  Requests that
+// fail because the connection was refused are retried regardless of their method since they never reached the UDM,
+// while other transient failures - 502/503/504/429 responses, connection resets and timeouts - are only retried for
+// the methods in Methods.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts made for a request, including the first one.  The retry of a
+	// request whose session was rejected by the UDM is made in addition to these attempts.
+	MaxAttempts int
+
+	// MinWait is the time to wait before the first retry.
+	MinWait time.Duration
+
+	// MaxWait is the maximum time to wait between attempts.
+	MaxWait time.Duration
+
+	// MaxElapsed is the time since the first attempt after which a request is no longer retried.
+	MaxElapsed time.Duration
+
+	// Methods holds the HTTP methods which are safe to retry after the request may have reached the UDM.
+	Methods []string
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DefaultRetryMaxAttempts,
+		MinWait:     DefaultRetryMinWait,
+		MaxWait:     DefaultRetryMaxWait,
+		MaxElapsed:  DefaultRetryMaxElapsed,
+		Methods: []string{
+			http.MethodDelete,
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodOptions,
+			http.MethodPut,
+		},
+	}
+}
+
+// requestStartKey is the context key used to store the time a request was first attempted.
+type requestStartKey struct{}
+
+// retryTransientFailure is a retry condition which retries requests that failed with a transient error according to
+// the client's retry policy.
+func (c *Client) retryTransientFailure(resp *resty.Response, err error) bool {
+	if resp == nil {
+		// the request failed before it was sent (eg: in a middleware)
+		return false
+	}
+	req := resp.Request
+	ctx := req.Context()
+	attempts := req.Attempt
+	if state, ok := ctx.Value(authenticatedRequestKey{}).(*authenticatedRequest); ok && state.retried {
+		// the retry with a new session is not one of the policy's attempts
+		attempts--
+	}
+	if ctx.Err() != nil || attempts >= c.retryPolicy.MaxAttempts {
+		return false
+	}
+	if start, ok := ctx.Value(requestStartKey{}).(time.Time); ok && time.Since(start) >= c.retryPolicy.MaxElapsed {
+		return false
+	}
+
+	var reason string
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		reason = "connection refused"
+	case !slices.Contains(c.retryPolicy.Methods, req.Method):
+		return false
+	case err != nil && isTransientError(err):
+		reason = err.Error()
+	case err == nil && isTransientStatusCode(resp.StatusCode()):
+		reason = resp.Status()
+	default:
+		return false
+	}
+	tflog.Warn(ctx, "request failed with a transient error - retrying", map[string]any{
+		"method":  req.Method,
+		"url":     req.URL,
+		"attempt": req.Attempt,
+		"reason":  reason,
+	})
+	return true
+}
+
+// isTransientError returns whether or not a request error is likely to go away if the request is retried.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isTransientStatusCode returns whether or not a response status code indicates the UDM is temporarily unavailable.
+func isTransientStatusCode(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout, http.StatusTooManyRequests:
+		return true
+	}
+	return false
+}
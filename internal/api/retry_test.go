@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestTransientFailuresAreRetried(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+
+	// idempotent requests are retried
+	server.FailRequests(http.MethodGet, staticDNSRecordsPath, http.StatusServiceUnavailable, 2)
+	if _, err := c.GetStaticDNSRecords(ctx, DefaultSite); err != nil {
+		t.Fatalf("GetStaticDNSRecords() returned an error: %s", err)
+	}
+	if got := server.RequestCount(http.MethodGet, staticDNSRecordsPath); got != 3 {
+		t.Errorf("list requests = %d, want 3", got)
+	}
+
+	// non-idempotent requests are not
+	server.FailRequests(http.MethodPost, staticDNSRecordsPath, http.StatusServiceUnavailable, 1)
+	_, err := c.CreateStaticDNSRecord(ctx, DefaultSite, StaticDNSRecord{Key: "a.example.com", RecordType: "A", Value: "10.0.0.1"})
+	if err == nil {
+		t.Fatal("CreateStaticDNSRecord() did not return an error")
+	}
+	if got := server.RequestCount(http.MethodPost, staticDNSRecordsPath); got != 1 {
+		t.Errorf("create requests = %d, want 1", got)
+	}
+}
+
+func TestSingleAttemptIsNotRetried(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	config := testClientConfig(server)
+	config.RetryPolicy.MaxAttempts = 1
+	c, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() returned an error: %s", err)
+	}
+	if err := c.Login(ctx, Credentials{Username: udmmock.DefaultUsername, Password: udmmock.DefaultPassword}); err != nil {
+		t.Fatalf("Login() returned an error: %s", err)
+	}
+
+	server.FailRequests(http.MethodGet, staticDNSRecordsPath, http.StatusServiceUnavailable, 1)
+	if _, err := c.GetStaticDNSRecords(ctx, DefaultSite); err == nil {
+		t.Fatal("GetStaticDNSRecords() did not return an error")
+	}
+	if got := server.RequestCount(http.MethodGet, staticDNSRecordsPath); got != 1 {
+		t.Errorf("list requests = %d, want 1", got)
+	}
+
+	// a rejected session is still refreshed
+	server.ExpireSessions()
+	if _, err := c.GetStaticDNSRecords(ctx, DefaultSite); err != nil {
+		t.Fatalf("GetStaticDNSRecords() after the session expired returned an error: %s", err)
+	}
+}
+
+func TestSessionRetryDoesNotCountAsAttempt(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	config := testClientConfig(server)
+	config.RetryPolicy.MaxAttempts = 2
+	c, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() returned an error: %s", err)
+	}
+	if err := c.Login(ctx, Credentials{Username: udmmock.DefaultUsername, Password: udmmock.DefaultPassword}); err != nil {
+		t.Fatalf("Login() returned an error: %s", err)
+	}
+
+	// the transient failure uses up the policy's retry and the rejected session gets one of its own
+	server.ExpireSessions()
+	server.FailRequests(http.MethodGet, staticDNSRecordsPath, http.StatusServiceUnavailable, 1)
+	if _, err := c.GetStaticDNSRecords(ctx, DefaultSite); err != nil {
+		t.Fatalf("GetStaticDNSRecords() returned an error: %s", err)
+	}
+	if got := server.RequestCount(http.MethodGet, staticDNSRecordsPath); got != 3 {
+		t.Errorf("list requests = %d, want 3", got)
+	}
+}
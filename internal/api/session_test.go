@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// sessionTestServer is a minimal UDM which hands out a new JWT on every login and only accepts the JWT it issued last.
+type sessionTestServer struct {
+	*httptest.Server
+
+	lifetimes []time.Duration // lifetime of the JWT issued by each login, the last one is used once they run out
+
+	lock    sync.Mutex
+	jwt     string   // the only JWT which is accepted
+	logins  int      // number of logins
+	cookies []string // JWTs sent with each request for the network app info
+}
+
+func newSessionTestServer(t *testing.T, lifetimes ...time.Duration) *sessionTestServer {
+	t.Helper()
+	s := &sessionTestServer{lifetimes: lifetimes}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/auth/login", s.handleLogin)
+	mux.HandleFunc("GET /proxy/network/v2/api/info", s.handleInfo)
+	s.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *sessionTestServer) handleLogin(w http.ResponseWriter, _ *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	lifetime := time.Hour
+	if len(s.lifetimes) > 0 {
+		lifetime = s.lifetimes[min(s.logins, len(s.lifetimes)-1)]
+	}
+	s.logins++
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(lifetime)),
+			ID:        fmt.Sprint(s.logins),
+		},
+		CsrfToken: "csrf",
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.jwt = token
+	http.SetCookie(w, &http.Cookie{Name: JWTCookieName, Value: token, Path: "/"})
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, `{"username":"admin"}`)
+}
+
+func (s *sessionTestServer) handleInfo(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	cookie, err := r.Cookie(JWTCookieName)
+	if err != nil {
+		http.Error(w, `{"error":"no session"}`, http.StatusUnauthorized)
+		return
+	}
+	s.cookies = append(s.cookies, cookie.Value)
+	if cookie.Value != s.jwt {
+		http.Error(w, `{"error":"session rejected"}`, http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, `{"system":{"version":"8.6.9"}}`)
+}
+
+// revokeSession makes the server reject the JWT it issued last, as if the session was invalidated on the UDM.
+func (s *sessionTestServer) revokeSession() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.jwt = "revoked"
+}
+
+// newSessionTestClient creates a client for a sessionTestServer.
+func newSessionTestClient(t *testing.T, s *sessionTestServer) *Client {
+	t.Helper()
+	c, err := NewClient(ClientConfig{
+		Hostname:               strings.TrimPrefix(s.URL, "https://"),
+		Site:                   "default",
+		IgnoreUntrustedSSLCert: true,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() returned an error: %s", err)
+	}
+	return c
+}
+
+// login logs into a sessionTestServer.
+func login(c *Client) error {
+	return c.Login(context.Background(), Credentials{Username: "admin", Password: "password"})
+}
+
+func TestRejectedSessionIsRetriedWithNewCookie(t *testing.T) {
+	s := newSessionTestServer(t)
+	c := newSessionTestClient(t, s)
+	if err := login(c); err != nil {
+		t.Fatalf("Login() returned an error: %s", err)
+	}
+	rejectedJWT := c.session().JWT
+
+	s.revokeSession()
+	if _, err := c.GetNetworkAppInfo(context.Background()); err != nil {
+		t.Fatalf("GetNetworkAppInfo() returned an error: %s", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.logins != 2 {
+		t.Errorf("logins = %d, want 2", s.logins)
+	}
+	if n := len(s.cookies); n != 3 || s.cookies[1] != rejectedJWT || s.cookies[2] == rejectedJWT {
+		t.Errorf("JWTs sent = %v, want the rejected JWT followed by a new one", s.cookies)
+	}
+}
+
+func TestExpiringSessionIsRefreshed(t *testing.T) {
+	// the first session expires within the refresh window, so the next request has to log in again first
+	s := newSessionTestServer(t, sessionRefreshWindow/2, time.Hour)
+
+	c := newSessionTestClient(t, s)
+	done := make(chan error)
+	go func() {
+		done <- login(c)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Login() returned an error: %s", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("refreshing the session did not finish - the login is likely deadlocked")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.logins != 2 {
+		t.Errorf("logins = %d, want 2", s.logins)
+	}
+	if session := c.session(); session.JWT != s.jwt || session.expiresSoon() {
+		t.Errorf("session = %+v, want the refreshed session", session)
+	}
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type getSitesResponseSuccess struct {
+	Meta struct {
+		RC      string `json:"rc"`
+		Message string `json:"msg"`
+	} `json:"meta"`
+	Data []Site `json:"data"`
+}
+
+type siteManagerRequest struct {
+	Command     string `json:"cmd"`
+	Description string `json:"desc,omitempty"`
+	Site        string `json:"site,omitempty"`
+}
+
+type siteManagerResponseSuccess struct {
+	Meta struct {
+		RC      string `json:"rc"`
+		Message string `json:"msg"`
+	} `json:"meta"`
+	Data []Site `json:"data"`
+}
+
+type Site struct {
+	Description string       `json:"desc"`
+	Health      []SiteHealth `json:"health,omitempty"`
+	HiddenID    string       `json:"attr_hidden_id,omitempty"`
+	ID          string       `json:"_id"`
+	Name        string       `json:"name"`
+	NoDelete    bool         `json:"attr_no_delete,omitempty"`
+	Role        string       `json:"role,omitempty"`
+}
+
+// SiteHealth holds the status of one subsystem (eg: wan, lan, wlan) of a site.
+type SiteHealth struct {
+	NumAdopted      int    `json:"num_adopted"`
+	NumDisconnected int    `json:"num_disconnected"`
+	NumGuest        int    `json:"num_guest"`
+	NumPending      int    `json:"num_pending"`
+	NumUser         int    `json:"num_user"`
+	Status          string `json:"status"`
+	Subsystem       string `json:"subsystem"`
+}
+
+// ClientCount returns the number of clients connected to the site.
+func (s Site) ClientCount() int {
+	count := 0
+	for _, health := range s.Health {
+		count += health.NumUser + health.NumGuest
+	}
+	return count
+}
+
+// DeviceCount returns the number of devices adopted by the site.
+func (s Site) DeviceCount() int {
+	count := 0
+	for _, health := range s.Health {
+		count += health.NumAdopted
+	}
+	return count
+}
+
+// CreateSite creates a new site with the given description.  The UDM picks the name of the site.
+func (c *Client) CreateSite(ctx context.Context, description string) (Site, error) {
+	ctx = c.addClientContext(ctx)
+	ctx = tflog.SetField(ctx, "description", description)
+
+	// sites are managed through any existing site, so the default site is used
+	//
+	// POST /proxy/network/api/s/:site/cmd/sitemgr
+	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/api/s/%s/cmd/sitemgr", c.site))
+	tflog.Debug(ctx, "creating site", map[string]any{
+		"url": url,
+	})
+	apiResponseSuccess := siteManagerResponseSuccess{}
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetBody(siteManagerRequest{
+			Command:     "add-site",
+			Description: description,
+		}).
+		SetResult(&apiResponseSuccess).
+		SetError(&apiResponseError).
+		Post(url)
+	c.cache.invalidate(collectionSites)
+	if err != nil {
+		tflog.Error(ctx, "failed to execute POST request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return Site{}, err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to create site", apiErr.logFields())
+		return Site{}, fmt.Errorf("failed to create site: %w", apiErr)
+	}
+	if len(apiResponseSuccess.Data) == 0 {
+		tflog.Error(ctx, "no site was returned by the API")
+		return Site{}, fmt.Errorf("failed to create site: no site was returned by the API")
+	}
+	return apiResponseSuccess.Data[0], nil
+}
+
+// DeleteSite deletes the site with the given ID along with everything configured in it.
+func (c *Client) DeleteSite(ctx context.Context, id string) error {
+	ctx = c.addClientContext(ctx)
+	ctx = tflog.SetField(ctx, "id", id)
+
+	// POST /proxy/network/api/s/:site/cmd/sitemgr
+	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/api/s/%s/cmd/sitemgr", c.site))
+	tflog.Debug(ctx, "deleting site", map[string]any{
+		"url": url,
+	})
+	apiResponseSuccess := siteManagerResponseSuccess{}
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetBody(siteManagerRequest{
+			Command: "delete-site",
+			Site:    id,
+		}).
+		SetResult(&apiResponseSuccess).
+		SetError(&apiResponseError).
+		Post(url)
+	c.cache.invalidate(collectionSites)
+	if err != nil {
+		tflog.Error(ctx, "failed to execute POST request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to delete site", apiErr.logFields())
+		return fmt.Errorf("failed to delete site: %w", apiErr)
+	}
+	return nil
+}
+
+// GetSites retrieves every site along with its health, which the user has access to.
+func (c *Client) GetSites(ctx context.Context) ([]Site, error) {
+	ctx = c.addClientContext(ctx)
+	return cachedCollection(ctx, c, collectionSites, func() ([]Site, error) {
+		return c.listSites(ctx)
+	})
+}
+
+// listSites retrieves all sites from the UDM, bypassing the cache.
+func (c *Client) listSites(ctx context.Context) ([]Site, error) {
+	// GET /proxy/network/api/stat/sites
+	url, req := c.newAuthenticatedRequest(ctx, "/proxy/network/api/stat/sites")
+	tflog.Debug(ctx, "retrieving sites", map[string]any{
+		"url": url,
+	})
+	apiResponseSuccess := getSitesResponseSuccess{}
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetResult(&apiResponseSuccess).
+		SetError(&apiResponseError).
+		Get(url)
+	if err != nil {
+		tflog.Error(ctx, "failed to execute GET request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return nil, err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to retrieve sites", apiErr.logFields())
+		return nil, fmt.Errorf("failed to retrieve sites: %w", apiErr)
+	}
+	return apiResponseSuccess.Data, nil
+}
+
+// GetSite retrieves the site with the given ID or name.
+func (c *Client) GetSite(ctx context.Context, idOrName string) (Site, error) {
+	ctx = tflog.SetField(ctx, "id", idOrName)
+	sites, err := c.GetSites(ctx)
+	if err != nil {
+		return Site{}, err
+	}
+	ctx = c.addClientContext(ctx)
+
+	// IDs and names never collide as IDs are 24 hexadecimal digits while names are shorter
+	tflog.Debug(ctx, "searching for site")
+	for _, site := range sites {
+		if site.ID == idOrName || site.Name == idOrName {
+			tflog.Debug(ctx, "site was located", map[string]any{"site": site})
+			return site, nil
+		}
+	}
+	tflog.Warn(ctx, "site not found")
+	return Site{}, fmt.Errorf("%w: no site found with an ID or name of '%s'", ErrNotFound, idOrName)
+}
+
+// UpdateSite changes the description of the site with the given name.  The name of a site cannot be changed.
+func (c *Client) UpdateSite(ctx context.Context, name, description string) (Site, error) {
+	ctx = c.addSiteContext(ctx, name)
+	ctx = tflog.SetField(ctx, "description", description)
+
+	// a site is updated through itself
+	//
+	// POST /proxy/network/api/s/:site/cmd/sitemgr
+	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/api/s/%s/cmd/sitemgr", name))
+	tflog.Debug(ctx, "updating site", map[string]any{
+		"url": url,
+	})
+	apiResponseSuccess := siteManagerResponseSuccess{}
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetBody(siteManagerRequest{
+			Command:     "update-site",
+			Description: description,
+		}).
+		SetResult(&apiResponseSuccess).
+		SetError(&apiResponseError).
+		Post(url)
+	c.cache.invalidate(collectionSites)
+	if err != nil {
+		tflog.Error(ctx, "failed to execute POST request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return Site{}, err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to update site", apiErr.logFields())
+		return Site{}, fmt.Errorf("failed to update site: %w", apiErr)
+	}
+
+	// the updated site is not always returned, in which case it is read back instead
+	if len(apiResponseSuccess.Data) == 0 {
+		return c.GetSite(ctx, name)
+	}
+	return apiResponseSuccess.Data[0], nil
+}
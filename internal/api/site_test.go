@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestSiteLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+
+	created, err := c.CreateSite(ctx, "Branch Office")
+	if err != nil {
+		t.Fatalf("CreateSite() returned an error: %s", err)
+	}
+	if created.ID == "" || created.Name == "" || created.Description != "Branch Office" {
+		t.Fatalf("CreateSite() = %+v, want an ID, a name and the description", created)
+	}
+
+	// the new site can be looked up by its ID or its name and can be managed like any other site
+	for _, idOrName := range []string{created.ID, created.Name} {
+		if got, err := c.GetSite(ctx, idOrName); err != nil || got.ID != created.ID {
+			t.Errorf("GetSite(%q) = %+v, %v, want the created site", idOrName, got, err)
+		}
+	}
+	if _, err := c.CreateStaticDNSRecord(ctx, created.Name, StaticDNSRecord{
+		Key:        "nas.branch.example.com",
+		RecordType: "A",
+		Value:      "10.20.0.10",
+	}); err != nil {
+		t.Errorf("CreateStaticDNSRecord() in the new site returned an error: %s", err)
+	}
+
+	updated, err := c.UpdateSite(ctx, created.Name, "Branch Office (Berlin)")
+	if err != nil {
+		t.Fatalf("UpdateSite() returned an error: %s", err)
+	}
+	if updated.ID != created.ID || updated.Name != created.Name || updated.Description != "Branch Office (Berlin)" {
+		t.Errorf("UpdateSite() = %+v, want the site with the new description", updated)
+	}
+	if got, _ := c.GetSite(ctx, created.ID); got.Description != updated.Description {
+		t.Errorf("description after update = %q, want %q", got.Description, updated.Description)
+	}
+
+	if err := c.DeleteSite(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteSite() returned an error: %s", err)
+	}
+	if _, err := c.GetSite(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetSite() after delete error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestGetSitesCountsDevicesAndClients(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	server.AddObject(udmmock.DefaultSite, udmmock.CollectionDevices, map[string]any{"mac": "00:00:00:00:00:01"})
+	server.AddObject(udmmock.DefaultSite, udmmock.CollectionDevices, map[string]any{"mac": "00:00:00:00:00:02"})
+	server.AddObject(udmmock.DefaultSite, udmmock.CollectionClientDevices, map[string]any{"mac": "00:00:00:00:00:03"})
+	server.AddSite("branch", "Branch Office")
+	c := newLoggedInClient(t, server)
+
+	sites, err := c.GetSites(ctx)
+	if err != nil {
+		t.Fatalf("GetSites() returned an error: %s", err)
+	}
+	if len(sites) != 2 || sites[0].Name != "branch" || sites[1].Name != DefaultSite {
+		t.Fatalf("GetSites() = %+v, want the branch and default sites", sites)
+	}
+	if n := sites[1].DeviceCount(); n != 2 {
+		t.Errorf("DeviceCount() = %d, want 2", n)
+	}
+	if n := sites[1].ClientCount(); n != 1 {
+		t.Errorf("ClientCount() = %d, want 1", n)
+	}
+	if n := sites[0].DeviceCount(); n != 0 {
+		t.Errorf("DeviceCount() of the branch site = %d, want 0", n)
+	}
+}
+
+func TestDeleteDefaultSiteFails(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+
+	site, err := c.GetSite(ctx, DefaultSite)
+	if err != nil {
+		t.Fatalf("GetSite() returned an error: %s", err)
+	}
+	err = c.DeleteSite(ctx, site.ID)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Message == "" {
+		t.Errorf("DeleteSite() error = %v, want an *Error with HTTP 400 and a message", err)
+	}
+}
@@ -9,40 +9,12 @@ import (
 
 type createStaticDNSRecordsResponseSuccess StaticDNSRecord
 
-type createStaticDNSRecordsResponseError struct {
-	Code      string `json:"code"`
-	Details   any    `json:"details"`
-	ErrorCode int    `json:"errorCode"`
-	Message   string `json:"message"`
-}
-
 type deleteStaticDNSRecordsResponseSuccess struct{}
 
-type deleteStaticDNSRecordsResponseError struct {
-	Code      string `json:"code"`
-	Details   any    `json:"details"`
-	ErrorCode int    `json:"errorCode"`
-	Message   string `json:"message"`
-}
-
 type getStaticDNSRecordsResponseSuccess []StaticDNSRecord
 
-type getStaticDNSRecordsResponseError struct {
-	Code      string `json:"code"`
-	Details   any    `json:"details"`
-	ErrorCode int    `json:"errorCode"`
-	Message   string `json:"message"`
-}
-
 type updateStaticDNSRecordsResponseSuccess StaticDNSRecord
 
-type updateStaticDNSRecordsResponseError struct {
-	Code      string `json:"code"`
-	Details   any    `json:"details"`
-	ErrorCode int    `json:"errorCode"`
-	Message   string `json:"message"`
-}
-
 type StaticDNSRecord struct {
 	ID         string `json:"_id,omitempty"`
 	Enabled    bool   `json:"enabled"`
@@ -55,26 +27,29 @@ type StaticDNSRecord struct {
 	Weight     int    `json:"weight,omitempty"`
 }
 
-func (c *Client) CreateStaticDNSRecord(ctx context.Context, record StaticDNSRecord) (StaticDNSRecord, error) {
-	ctx = c.addClientContext(ctx)
+func (c *Client) CreateStaticDNSRecord(ctx context.Context, site string, record StaticDNSRecord) (
+	StaticDNSRecord, error) {
+
+	ctx = c.addSiteContext(ctx, site)
 	ctx = tflog.SetField(ctx, "record_type", record.RecordType)
 	ctx = tflog.SetField(ctx, "key", record.Key)
 	ctx = tflog.SetField(ctx, "value", record.Value)
 	ctx = tflog.SetField(ctx, "ttl", record.TTL)
 
 	// POST /proxy/network/v2/api/site/:site/static-dns
-	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/v2/api/site/%s/static-dns", c.site))
+	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/v2/api/site/%s/static-dns", site))
 	tflog.Debug(ctx, "creating static DNS record", map[string]any{
 		"url":    url,
-		"record": record,
+		"record": redactObject(record),
 	})
 	apiResponseSuccess := createStaticDNSRecordsResponseSuccess{}
-	apiResponseError := createStaticDNSRecordsResponseError{}
+	apiResponseError := errorResponse{}
 	resp, err := req.
 		SetBody(record).
 		SetResult(&apiResponseSuccess).
 		SetError(&apiResponseError).
 		Post(url)
+	c.cache.invalidate(collectionCacheKey(site, collectionStaticDNSRecords))
 	if err != nil {
 		tflog.Error(ctx, "failed to execute POST request", map[string]any{
 			"error_message": err.Error(),
@@ -85,31 +60,28 @@ func (c *Client) CreateStaticDNSRecord(ctx context.Context, record StaticDNSReco
 
 	// if a non-200 error was returned by the API, something went wrong
 	if statusCode != 200 {
-		tflog.Error(ctx, "failed to create static DNS record", map[string]any{
-			"message":    apiResponseError.Message,
-			"error_code": apiResponseError.ErrorCode,
-			"code":       apiResponseError.Code,
-			"details":    apiResponseError.Details,
-		})
-		return StaticDNSRecord{}, fmt.Errorf("failed to create static DNS record: %s", apiResponseError.Message)
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to create static DNS record", apiErr.logFields())
+		return StaticDNSRecord{}, fmt.Errorf("failed to create static DNS record: %w", apiErr)
 	}
 	return StaticDNSRecord(apiResponseSuccess), nil
 }
 
-func (c *Client) DeleteStaticDNSRecord(ctx context.Context, id string) error {
-	ctx = c.addClientContext(ctx)
+func (c *Client) DeleteStaticDNSRecord(ctx context.Context, site, id string) error {
+	ctx = c.addSiteContext(ctx, site)
 
 	// DELETE /proxy/network/v2/api/site/:site/static-dns/:id
-	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/v2/api/site/%s/static-dns/%s", c.site, id))
+	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/v2/api/site/%s/static-dns/%s", site, id))
 	tflog.Debug(ctx, "deleting static DNS record", map[string]any{
 		"url": url,
 	})
 	apiResponseSuccess := deleteStaticDNSRecordsResponseSuccess{}
-	apiResponseError := deleteStaticDNSRecordsResponseError{}
+	apiResponseError := errorResponse{}
 	resp, err := req.
 		SetResult(&apiResponseSuccess).
 		SetError(&apiResponseError).
 		Delete(url)
+	c.cache.invalidate(collectionCacheKey(site, collectionStaticDNSRecords))
 	if err != nil {
 		tflog.Error(ctx, "failed to execute DELETE request", map[string]any{
 			"error_message": err.Error(),
@@ -120,27 +92,30 @@ func (c *Client) DeleteStaticDNSRecord(ctx context.Context, id string) error {
 
 	// if a non-200 error was returned by the API, something went wrong
 	if statusCode != 200 {
-		tflog.Error(ctx, "failed to delete static DNS record", map[string]any{
-			"message":    apiResponseError.Message,
-			"error_code": apiResponseError.ErrorCode,
-			"code":       apiResponseError.Code,
-			"details":    apiResponseError.Details,
-		})
-		return fmt.Errorf("failed to delete static DNS record: %s", apiResponseError.Message)
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to delete static DNS record", apiErr.logFields())
+		return fmt.Errorf("failed to delete static DNS record: %w", apiErr)
 	}
 	return nil
 }
 
-func (c *Client) GetStaticDNSRecords(ctx context.Context) ([]StaticDNSRecord, error) {
-	ctx = c.addClientContext(ctx)
+func (c *Client) GetStaticDNSRecords(ctx context.Context, site string) ([]StaticDNSRecord, error) {
+	ctx = c.addSiteContext(ctx, site)
+	key := collectionCacheKey(site, collectionStaticDNSRecords)
+	return cachedCollection(ctx, c, key, func() ([]StaticDNSRecord, error) {
+		return c.listStaticDNSRecords(ctx, site)
+	})
+}
 
+// listStaticDNSRecords retrieves all static DNS records from the UDM, bypassing the cache.
+func (c *Client) listStaticDNSRecords(ctx context.Context, site string) ([]StaticDNSRecord, error) {
 	// GET /proxy/network/v2/api/site/:site/static-dns
-	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/v2/api/site/%s/static-dns", c.site))
+	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/v2/api/site/%s/static-dns", site))
 	tflog.Debug(ctx, "retrieving static DNS records", map[string]any{
 		"url": url,
 	})
 	apiResponseSuccess := getStaticDNSRecordsResponseSuccess{}
-	apiResponseError := getStaticDNSRecordsResponseError{}
+	apiResponseError := errorResponse{}
 	resp, err := req.
 		SetResult(&apiResponseSuccess).
 		SetError(&apiResponseError).
@@ -155,38 +130,37 @@ func (c *Client) GetStaticDNSRecords(ctx context.Context) ([]StaticDNSRecord, er
 
 	// if a non-200 error was returned by the API, something went wrong
 	if statusCode != 200 {
-		tflog.Error(ctx, "failed to retrieve static DNS records", map[string]any{
-			"body": resp.Body(),
-		})
-		return nil, fmt.Errorf("failed to retrieve static DNS records")
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to retrieve static DNS records", apiErr.logFields())
+		return nil, fmt.Errorf("failed to retrieve static DNS records: %w", apiErr)
 	}
 	return []StaticDNSRecord(apiResponseSuccess), nil
 }
 
-func (c *Client) GetStaticDNSRecord(ctx context.Context, id string) (StaticDNSRecord, error) {
+func (c *Client) GetStaticDNSRecord(ctx context.Context, site, id string) (StaticDNSRecord, error) {
 	ctx = tflog.SetField(ctx, "id", id)
-	records, err := c.GetStaticDNSRecords(ctx)
+	records, err := c.GetStaticDNSRecords(ctx, site)
 	if err != nil {
 		return StaticDNSRecord{}, err
 	}
-	ctx = c.addClientContext(ctx)
+	ctx = c.addSiteContext(ctx, site)
 
 	// find the ID in question
 	tflog.Debug(ctx, "searching for static DNS record")
 	for _, record := range records {
 		if record.ID == id {
-			tflog.Debug(ctx, "static DNS record was located", map[string]any{"record": record})
+			tflog.Debug(ctx, "static DNS record was located", map[string]any{"record": redactObject(record)})
 			return record, nil
 		}
 	}
 	tflog.Warn(ctx, "static DNS record not found")
-	return StaticDNSRecord{}, fmt.Errorf("no static DNS record found with an ID of '%s'", id)
+	return StaticDNSRecord{}, fmt.Errorf("%w: no static DNS record found with an ID of '%s'", ErrNotFound, id)
 }
 
-func (c *Client) UpdateStaticDNSRecord(ctx context.Context, id string, record StaticDNSRecord) (
+func (c *Client) UpdateStaticDNSRecord(ctx context.Context, site, id string, record StaticDNSRecord) (
 	StaticDNSRecord, error) {
 
-	ctx = c.addClientContext(ctx)
+	ctx = c.addSiteContext(ctx, site)
 	ctx = tflog.SetField(ctx, "id", id)
 	ctx = tflog.SetField(ctx, "record_type", record.RecordType)
 	ctx = tflog.SetField(ctx, "key", record.Key)
@@ -194,18 +168,19 @@ func (c *Client) UpdateStaticDNSRecord(ctx context.Context, id string, record St
 	ctx = tflog.SetField(ctx, "ttl", record.TTL)
 
 	// PUT /proxy/network/v2/api/site/:site/static-dns/:id
-	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/v2/api/site/%s/static-dns/%s", c.site, id))
+	url, req := c.newAuthenticatedRequest(ctx, fmt.Sprintf("/proxy/network/v2/api/site/%s/static-dns/%s", site, id))
 	tflog.Debug(ctx, "updating static DNS record", map[string]any{
 		"url":    url,
-		"record": record,
+		"record": redactObject(record),
 	})
 	apiResponseSuccess := updateStaticDNSRecordsResponseSuccess{}
-	apiResponseError := updateStaticDNSRecordsResponseError{}
+	apiResponseError := errorResponse{}
 	resp, err := req.
 		SetBody(record).
 		SetResult(&apiResponseSuccess).
 		SetError(&apiResponseError).
 		Put(url)
+	c.cache.invalidate(collectionCacheKey(site, collectionStaticDNSRecords))
 	if err != nil {
 		tflog.Error(ctx, "failed to execute PUT request", map[string]any{
 			"error_message": err.Error(),
@@ -216,13 +191,9 @@ func (c *Client) UpdateStaticDNSRecord(ctx context.Context, id string, record St
 
 	// if a non-200 error was returned by the API, something went wrong
 	if statusCode != 200 {
-		tflog.Error(ctx, "failed to update static DNS record", map[string]any{
-			"message":    apiResponseError.Message,
-			"error_code": apiResponseError.ErrorCode,
-			"code":       apiResponseError.Code,
-			"details":    apiResponseError.Details,
-		})
-		return StaticDNSRecord{}, fmt.Errorf("failed to update static DNS record: %s", apiResponseError.Message)
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, "failed to update static DNS record", apiErr.logFields())
+		return StaticDNSRecord{}, fmt.Errorf("failed to update static DNS record: %w", apiErr)
 	}
 	return StaticDNSRecord(apiResponseSuccess), nil
 }
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+const staticDNSRecordsPath = "/proxy/network/v2/api/site/default/static-dns"
+
+func TestStaticDNSRecordLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+
+	created, err := c.CreateStaticDNSRecord(ctx, DefaultSite, StaticDNSRecord{
+		Enabled:    true,
+		Key:        "nas.example.com",
+		RecordType: "A",
+		TTL:        300,
+		Value:      "192.168.1.10",
+	})
+	if err != nil {
+		t.Fatalf("CreateStaticDNSRecord() returned an error: %s", err)
+	}
+	if created.ID == "" {
+		t.Fatal("CreateStaticDNSRecord() returned a record without an ID")
+	}
+
+	got, err := c.GetStaticDNSRecord(ctx, DefaultSite, created.ID)
+	if err != nil {
+		t.Fatalf("GetStaticDNSRecord() returned an error: %s", err)
+	}
+	if got != created {
+		t.Errorf("GetStaticDNSRecord() = %+v, want %+v", got, created)
+	}
+
+	update := created
+	update.Value = "192.168.1.11"
+	updated, err := c.UpdateStaticDNSRecord(ctx, DefaultSite, created.ID, update)
+	if err != nil {
+		t.Fatalf("UpdateStaticDNSRecord() returned an error: %s", err)
+	}
+	if updated.Value != update.Value {
+		t.Errorf("updated value = %q, want %q", updated.Value, update.Value)
+	}
+	if got, _ := c.GetStaticDNSRecord(ctx, DefaultSite, created.ID); got.Value != update.Value {
+		t.Errorf("value after update = %q, want %q", got.Value, update.Value)
+	}
+
+	if err := c.DeleteStaticDNSRecord(ctx, DefaultSite, created.ID); err != nil {
+		t.Fatalf("DeleteStaticDNSRecord() returned an error: %s", err)
+	}
+	if _, err := c.GetStaticDNSRecord(ctx, DefaultSite, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetStaticDNSRecord() after delete error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestStaticDNSRecordsAreSeparatedBySite(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	server.AddSite("branch", "Branch Office")
+	c := newLoggedInClient(t, server)
+
+	created, err := c.CreateStaticDNSRecord(ctx, "branch", StaticDNSRecord{
+		Key:        "nas.branch.example.com",
+		RecordType: "A",
+		Value:      "10.20.0.10",
+	})
+	if err != nil {
+		t.Fatalf("CreateStaticDNSRecord() returned an error: %s", err)
+	}
+	if _, ok := server.Object("branch", udmmock.CollectionStaticDNSRecords, created.ID); !ok {
+		t.Error("record was not created in the branch site")
+	}
+
+	// the record only exists in the site it was created in
+	if records, err := c.GetStaticDNSRecords(ctx, DefaultSite); err != nil || len(records) != 0 {
+		t.Errorf("GetStaticDNSRecords(default) = %v, %v, want no records", records, err)
+	}
+	if _, err := c.GetStaticDNSRecord(ctx, DefaultSite, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetStaticDNSRecord(default) error = %v, want %v", err, ErrNotFound)
+	}
+	if got, err := c.GetStaticDNSRecord(ctx, "branch", created.ID); err != nil || got != created {
+		t.Errorf("GetStaticDNSRecord(branch) = %+v, %v, want %+v", got, err, created)
+	}
+
+	// every site is managed with the same session
+	if n := server.RequestCount(http.MethodPost, "/api/auth/login"); n != 1 {
+		t.Errorf("logged in %d times, want 1", n)
+	}
+}
+
+func TestStaticDNSRecordErrors(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+
+	_, err := c.CreateStaticDNSRecord(ctx, DefaultSite,
+		StaticDNSRecord{Key: "bad.example.com", RecordType: "BOGUS", Value: "x"})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Code == "" {
+		t.Errorf("CreateStaticDNSRecord() error = %v, want an *Error with HTTP 400 and a code", err)
+	}
+
+	err = c.DeleteStaticDNSRecord(ctx, DefaultSite, "000000000000000000000000")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("DeleteStaticDNSRecord() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestStaticDNSRecordsAreCached(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+	for _, key := range []string{"a.example.com", "b.example.com"} {
+		server.AddObject(udmmock.DefaultSite, udmmock.CollectionStaticDNSRecords, map[string]any{
+			"enabled":     true,
+			"key":         key,
+			"record_type": "A",
+			"value":       "10.0.0.1",
+		})
+	}
+
+	records, err := c.GetStaticDNSRecords(ctx, DefaultSite)
+	if err != nil {
+		t.Fatalf("GetStaticDNSRecords() returned an error: %s", err)
+	}
+	for _, record := range records {
+		if _, err := c.GetStaticDNSRecord(ctx, DefaultSite, record.ID); err != nil {
+			t.Fatalf("GetStaticDNSRecord() returned an error: %s", err)
+		}
+	}
+	if got := server.RequestCount(http.MethodGet, staticDNSRecordsPath); got != 1 {
+		t.Errorf("list requests = %d, want 1", got)
+	}
+
+	// any change to the collection invalidates the cache
+	if err := c.DeleteStaticDNSRecord(ctx, DefaultSite, records[0].ID); err != nil {
+		t.Fatalf("DeleteStaticDNSRecord() returned an error: %s", err)
+	}
+	records, err = c.GetStaticDNSRecords(ctx, DefaultSite)
+	if err != nil {
+		t.Fatalf("GetStaticDNSRecords() returned an error: %s", err)
+	}
+	if len(records) != 1 {
+		t.Errorf("records after delete = %d, want 1", len(records))
+	}
+	if got := server.RequestCount(http.MethodGet, staticDNSRecordsPath); got != 2 {
+		t.Errorf("list requests = %d, want 2", got)
+	}
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenerateTOTP(t *testing.T) {
+	// test vectors from RFC 6238 appendix B truncated to 6 digits
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tt := range tests {
+		got, err := GenerateTOTP(secret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("GenerateTOTP() returned an error: %s", err)
+		}
+		if got != tt.want {
+			t.Errorf("GenerateTOTP(%d) = %q, want %q", tt.unix, got, tt.want)
+		}
+	}
+
+	if _, err := GenerateTOTP("not base32!", time.Now()); err == nil {
+		t.Error("GenerateTOTP() with an invalid secret did not return an error")
+	}
+}
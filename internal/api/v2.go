@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// v2Collection describes a collection of the v2 API.
+type v2Collection struct {
+	// path of the collection within a site in the URL (eg: firewall/zone)
+	path string
+
+	// human-readable name of a single object in the collection, used in log and error messages (eg: firewall zone)
+	objectName string
+}
+
+// cacheKey returns the key of the collection within a site in the collection cache.
+func (vc v2Collection) cacheKey(site string) string {
+	return collectionCacheKey(site, "v2/"+vc.path)
+}
+
+// url returns the path of the collection within a site or of a single object if an ID is given.
+func (vc v2Collection) url(site, id string) string {
+	if id == "" {
+		return fmt.Sprintf("/proxy/network/v2/api/site/%s/%s", site, vc.path)
+	}
+	return fmt.Sprintf("/proxy/network/v2/api/site/%s/%s/%s", site, vc.path, id)
+}
+
+// createV2Object creates an object in a v2 collection and returns the object created by the UDM.
+func createV2Object[T any](ctx context.Context, c *Client, vc v2Collection, site string, object T) (T, error) {
+	var empty T
+	ctx = c.addSiteContext(ctx, site)
+
+	// POST /proxy/network/v2/api/site/:site/:collection
+	url, req := c.newAuthenticatedRequest(ctx, vc.url(site, ""))
+	tflog.Debug(ctx, fmt.Sprintf("creating %s", vc.objectName), map[string]any{
+		"url":    url,
+		"object": redactObject(object),
+	})
+	var apiResponseSuccess T
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetBody(object).
+		SetResult(&apiResponseSuccess).
+		SetError(&apiResponseError).
+		Post(url)
+	c.cache.invalidate(vc.cacheKey(site))
+	if err != nil {
+		tflog.Error(ctx, "failed to execute POST request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return empty, err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, fmt.Sprintf("failed to create %s", vc.objectName), apiErr.logFields())
+		return empty, fmt.Errorf("failed to create %s: %w", vc.objectName, apiErr)
+	}
+	return apiResponseSuccess, nil
+}
+
+// deleteV2Object deletes the object with the given ID from a v2 collection.
+func deleteV2Object(ctx context.Context, c *Client, vc v2Collection, site, id string) error {
+	ctx = c.addSiteContext(ctx, site)
+	ctx = tflog.SetField(ctx, "id", id)
+
+	// DELETE /proxy/network/v2/api/site/:site/:collection/:id
+	url, req := c.newAuthenticatedRequest(ctx, vc.url(site, id))
+	tflog.Debug(ctx, fmt.Sprintf("deleting %s", vc.objectName), map[string]any{
+		"url": url,
+	})
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetError(&apiResponseError).
+		Delete(url)
+	c.cache.invalidate(vc.cacheKey(site))
+	if err != nil {
+		tflog.Error(ctx, "failed to execute DELETE request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, fmt.Sprintf("failed to delete %s", vc.objectName), apiErr.logFields())
+		return fmt.Errorf("failed to delete %s: %w", vc.objectName, apiErr)
+	}
+	return nil
+}
+
+// getV2Objects retrieves all of the objects in a v2 collection, using the cached collection if there is one.
+func getV2Objects[T any](ctx context.Context, c *Client, vc v2Collection, site string) ([]T, error) {
+	ctx = c.addSiteContext(ctx, site)
+	return cachedCollection(ctx, c, vc.cacheKey(site), func() ([]T, error) {
+		return listV2Objects[T](ctx, c, vc, site)
+	})
+}
+
+// getV2Object retrieves the object with the given ID from a v2 collection.
+func getV2Object[T restObject](ctx context.Context, c *Client, vc v2Collection, site, id string) (T, error) {
+	var empty T
+	ctx = tflog.SetField(ctx, "id", id)
+	objects, err := getV2Objects[T](ctx, c, vc, site)
+	if err != nil {
+		return empty, err
+	}
+	ctx = c.addSiteContext(ctx, site)
+
+	// find the ID in question
+	tflog.Debug(ctx, fmt.Sprintf("searching for %s", vc.objectName))
+	for _, object := range objects {
+		if object.objectID() == id {
+			tflog.Debug(ctx, fmt.Sprintf("%s was located", vc.objectName), map[string]any{
+				"object": redactObject(object),
+			})
+			return object, nil
+		}
+	}
+	tflog.Warn(ctx, fmt.Sprintf("%s not found", vc.objectName))
+	return empty, fmt.Errorf("%w: no %s found with an ID of '%s'", ErrNotFound, vc.objectName, id)
+}
+
+// listV2Objects retrieves all of the objects in a v2 collection from the UDM, bypassing the cache.
+func listV2Objects[T any](ctx context.Context, c *Client, vc v2Collection, site string) ([]T, error) {
+	// GET /proxy/network/v2/api/site/:site/:collection
+	url, req := c.newAuthenticatedRequest(ctx, vc.url(site, ""))
+	tflog.Debug(ctx, fmt.Sprintf("retrieving %s objects", vc.objectName), map[string]any{
+		"url": url,
+	})
+	apiResponseSuccess := []T{}
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetResult(&apiResponseSuccess).
+		SetError(&apiResponseError).
+		Get(url)
+	if err != nil {
+		tflog.Error(ctx, "failed to execute GET request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return nil, err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, fmt.Sprintf("failed to retrieve %s objects", vc.objectName), apiErr.logFields())
+		return nil, fmt.Errorf("failed to retrieve %s objects: %w", vc.objectName, apiErr)
+	}
+	return apiResponseSuccess, nil
+}
+
+// updateV2Object replaces the object with the given ID in a v2 collection and returns the object updated by the UDM.
+func updateV2Object[T any](ctx context.Context, c *Client, vc v2Collection, site, id string, object T) (T, error) {
+	var empty T
+	ctx = c.addSiteContext(ctx, site)
+	ctx = tflog.SetField(ctx, "id", id)
+
+	// PUT /proxy/network/v2/api/site/:site/:collection/:id
+	url, req := c.newAuthenticatedRequest(ctx, vc.url(site, id))
+	tflog.Debug(ctx, fmt.Sprintf("updating %s", vc.objectName), map[string]any{
+		"url":    url,
+		"object": redactObject(object),
+	})
+	var apiResponseSuccess T
+	apiResponseError := errorResponse{}
+	resp, err := req.
+		SetBody(object).
+		SetResult(&apiResponseSuccess).
+		SetError(&apiResponseError).
+		Put(url)
+	c.cache.invalidate(vc.cacheKey(site))
+	if err != nil {
+		tflog.Error(ctx, "failed to execute PUT request", map[string]any{
+			"error_message": err.Error(),
+		})
+		return empty, err
+	}
+	statusCode, _, _ := c.logResponse(ctx, resp)
+
+	// if a non-200 error was returned by the API, something went wrong
+	if statusCode != 200 {
+		apiErr := newError(resp, &apiResponseError)
+		tflog.Error(ctx, fmt.Sprintf("failed to update %s", vc.objectName), apiErr.logFields())
+		return empty, fmt.Errorf("failed to update %s: %w", vc.objectName, apiErr)
+	}
+	return apiResponseSuccess, nil
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// FeatureStaticDNSRecords is the v2 static DNS record API.
+	FeatureStaticDNSRecords = Feature{Name: "Static DNS records", MinVersion: Version{Major: 8, Minor: 2}}
+
+	// FeatureZoneBasedFirewall is the zone-based firewall which replaced the legacy firewall rulesets.
+	FeatureZoneBasedFirewall = Feature{Name: "Zone-based firewall policies", MinVersion: Version{Major: 9}}
+)
+
+// Feature describes a feature of the network application which is only available in newer versions.
+type Feature struct {
+	// Name is the human-readable name of the feature.
+	Name string
+
+	// MinVersion is the first version of the network application with the feature.
+	MinVersion Version
+}
+
+// UnsupportedFeatureError is returned when the network application is too old for a feature.
+type UnsupportedFeatureError struct {
+	// Feature is the unsupported feature.
+	Feature Feature
+
+	// Version is the version of the network application.
+	Version Version
+}
+
+// Error returns a string representation of the error.
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s require Network application >= %s but the UDM is running %s", e.Feature.Name,
+		e.Feature.MinVersion, e.Version)
+}
+
+// Version is the semantic version of a network application release (eg: 9.0.114).
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses a version string as reported by the network application.
+//
+// A leading "v", pre-release and build suffixes (eg: "-beta.1") and any components after the patch number are ignored.
+// Missing minor and patch numbers are treated as 0.
+func ParseVersion(s string) (Version, error) {
+	version := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(version, "-+ "); i >= 0 {
+		version = version[:i]
+	}
+	if version == "" {
+		return Version{}, errors.New("empty version")
+	}
+
+	var numbers [3]int
+	for i, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version '%s'", s)
+		}
+		if i < len(numbers) {
+			numbers[i] = n
+		}
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// AtLeast returns whether or not the version is the same as or newer than another version.
+func (v Version) AtLeast(other Version) bool {
+	return v.Compare(other) >= 0
+}
+
+// Compare returns -1 if the version is older than another version, 1 if it is newer and 0 if they are the same.
+func (v Version) Compare(other Version) int {
+	for _, d := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		switch {
+		case d < 0:
+			return -1
+		case d > 0:
+			return 1
+		}
+	}
+	return 0
+}
+
+// String returns the version in the form major.minor.patch.
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// NetworkAppVersion returns the version of the network application collected when the client authenticated.
+func (c *Client) NetworkAppVersion() (Version, error) {
+	return ParseVersion(c.networkAppInfo.System.Version)
+}
+
+// RequireFeature returns an *UnsupportedFeatureError if the network application is too old for a feature.
+//
+// If the version of the network application is unknown or cannot be parsed, the feature is assumed to be supported so
+// the UDM itself has the final say.
+func (c *Client) RequireFeature(feature Feature) error {
+	version, err := c.NetworkAppVersion()
+	if err == nil && !version.AtLeast(feature.MinVersion) {
+		return &UnsupportedFeatureError{Feature: feature, Version: version}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Version
+	}{
+		{"9.0.114", Version{9, 0, 114}},
+		{"v8.6.9", Version{8, 6, 9}},
+		{"8.2", Version{8, 2, 0}},
+		{"7", Version{7, 0, 0}},
+		{"9.1.105-beta.2", Version{9, 1, 105}},
+		{"8.5.6.12345", Version{8, 5, 6}},
+	}
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseVersion(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "latest", "9.x.1", "9..1"} {
+		if _, err := ParseVersion(in); err == nil {
+			t.Errorf("ParseVersion(%q) returned no error", in)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b Version
+		want int
+	}{
+		{Version{9, 0, 114}, Version{9, 0, 114}, 0},
+		{Version{9, 0, 114}, Version{8, 6, 9}, 1},
+		{Version{8, 1, 127}, Version{8, 2, 0}, -1},
+		{Version{8, 2, 1}, Version{8, 2, 0}, 1},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Compare(tt.b); got != tt.want {
+			t.Errorf("%s.Compare(%s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRequireFeature(t *testing.T) {
+	tests := []struct {
+		version     string
+		unsupported bool
+	}{
+		{"9.0.114", false},
+		{"8.2.0", false},
+		{"8.1.127", true},
+		{"7.5.187", true},
+	}
+	for _, tt := range tests {
+		server := newTestServer(t, udmmock.Config{NetworkAppVersion: tt.version})
+		c := newLoggedInClient(t, server)
+
+		err := c.RequireFeature(FeatureStaticDNSRecords)
+		var unsupported *UnsupportedFeatureError
+		if got := errors.As(err, &unsupported); got != tt.unsupported {
+			t.Errorf("RequireFeature() with version %s = %v, want unsupported = %t", tt.version, err, tt.unsupported)
+		}
+	}
+
+	// features are assumed to be supported when the version is unknown
+	if err := (&Client{}).RequireFeature(FeatureStaticDNSRecords); err != nil {
+		t.Errorf("RequireFeature() without a version = %v, want nil", err)
+	}
+}
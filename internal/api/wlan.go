@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+)
+
+// wlans is the v1 REST collection holding wireless networks.
+var wlans = restCollection{name: "wlanconf", objectName: "WLAN"}
+
+type WLAN struct {
+	APGroupIDs            []string           `json:"ap_group_ids"`
+	APGroupMode           string             `json:"ap_group_mode,omitempty"`
+	BandSteering          bool               `json:"band_steering"`
+	Enabled               bool               `json:"enabled"`
+	HideSSID              bool               `json:"hide_ssid"`
+	ID                    string             `json:"_id,omitempty"`
+	L2Isolation           bool               `json:"l2_isolation"`
+	MACFilterEnabled      bool               `json:"mac_filter_enabled"`
+	MACFilterList         []string           `json:"mac_filter_list"`
+	MACFilterPolicy       string             `json:"mac_filter_policy,omitempty"`
+	MinRate2GDataRateKbps int                `json:"minrate_ng_data_rate_kbps,omitempty"`
+	MinRate2GEnabled      bool               `json:"minrate_ng_enabled"`
+	MinRate5GDataRateKbps int                `json:"minrate_na_data_rate_kbps,omitempty"`
+	MinRate5GEnabled      bool               `json:"minrate_na_enabled"`
+	Name                  string             `json:"name"`
+	NetworkConfID         string             `json:"networkconf_id"`
+	Passphrase            string             `json:"x_passphrase,omitempty"`
+	RADIUSProfileID       string             `json:"radiusprofile_id,omitempty"`
+	Schedule              []WLANScheduleSlot `json:"schedule_with_duration"`
+	ScheduleEnabled       bool               `json:"schedule_enabled"`
+	Security              string             `json:"security"`
+	SiteID                string             `json:"site_id,omitempty"`
+	WPA3Support           bool               `json:"wpa3_support"`
+	WPA3Transition        bool               `json:"wpa3_transition"`
+	WPAEncryption         string             `json:"wpa_enc,omitempty"`
+	WPAMode               string             `json:"wpa_mode,omitempty"`
+}
+
+// WLANScheduleSlot is a period of time during which a WLAN is broadcast when its schedule is enabled.
+type WLANScheduleSlot struct {
+	DurationMinutes int      `json:"duration_minutes"`
+	Name            string   `json:"name,omitempty"`
+	StartDaysOfWeek []string `json:"start_days_of_week"`
+	StartHour       int      `json:"start_hour"`
+	StartMinute     int      `json:"start_minute"`
+}
+
+func (w WLAN) objectID() string {
+	return w.ID
+}
+
+func (c *Client) CreateWLAN(ctx context.Context, site string, wlan WLAN) (WLAN, error) {
+	return createRESTObject(ctx, c, wlans, site, wlan)
+}
+
+func (c *Client) DeleteWLAN(ctx context.Context, site, id string) error {
+	return deleteRESTObject(ctx, c, wlans, site, id)
+}
+
+func (c *Client) GetWLANs(ctx context.Context, site string) ([]WLAN, error) {
+	return getRESTObjects[WLAN](ctx, c, wlans, site)
+}
+
+func (c *Client) GetWLAN(ctx context.Context, site, id string) (WLAN, error) {
+	return getRESTObject[WLAN](ctx, c, wlans, site, id)
+}
+
+func (c *Client) UpdateWLAN(ctx context.Context, site, id string, wlan WLAN) (WLAN, error) {
+	return updateRESTObject(ctx, c, wlans, site, id, wlan)
+}
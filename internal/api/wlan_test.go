@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestWLANFieldMapping(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, udmmock.Config{})
+	c := newLoggedInClient(t, server)
+
+	network, err := c.CreateNetwork(ctx, DefaultSite, Network{Name: "Guest", Purpose: "guest", VLAN: 30, VLANEnabled: true})
+	if err != nil {
+		t.Fatalf("CreateNetwork() returned an error: %s", err)
+	}
+	created, err := c.CreateWLAN(ctx, DefaultSite, WLAN{
+		APGroupIDs:    []string{},
+		Enabled:       true,
+		MACFilterList: []string{},
+		Name:          "Guest Wi-Fi",
+		NetworkConfID: network.ID,
+		Passphrase:    "correct horse battery",
+		Schedule: []WLANScheduleSlot{
+			{DurationMinutes: 600, StartDaysOfWeek: []string{"sat", "sun"}, StartHour: 8},
+		},
+		ScheduleEnabled: true,
+		Security:        "wpapsk",
+		WPAMode:         "wpa2",
+	})
+	if err != nil {
+		t.Fatalf("CreateWLAN() returned an error: %s", err)
+	}
+
+	// the passphrase and the schedule are stored in fields named differently from their attributes
+	object, _ := server.Object(udmmock.DefaultSite, udmmock.CollectionWLANs, created.ID)
+	if object["x_passphrase"] != "correct horse battery" {
+		t.Errorf("x_passphrase = %v, want %q", object["x_passphrase"], "correct horse battery")
+	}
+	schedule, _ := object["schedule_with_duration"].([]any)
+	if len(schedule) != 1 || schedule[0].(map[string]any)["duration_minutes"] != float64(600) {
+		t.Errorf("schedule_with_duration = %v, want a single 600 minute slot", object["schedule_with_duration"])
+	}
+
+	// a WLAN updated without a passphrase keeps its passphrase
+	update := created
+	update.Passphrase = ""
+	update.HideSSID = true
+	if _, err := c.UpdateWLAN(ctx, DefaultSite, created.ID, update); err != nil {
+		t.Fatalf("UpdateWLAN() returned an error: %s", err)
+	}
+	got, err := c.GetWLAN(ctx, DefaultSite, created.ID)
+	if err != nil {
+		t.Fatalf("GetWLAN() returned an error: %s", err)
+	}
+	if !got.HideSSID || got.Passphrase != "correct horse battery" {
+		t.Errorf("GetWLAN() = %+v, want a hidden SSID with the original passphrase", got)
+	}
+}
@@ -27,6 +27,7 @@ type clientDevicesDataSource struct {
 type clientDevicesDataSourceModel struct {
 	Devices []clientDeviceDataSourceModel      `tfsdk:"devices"`
 	Filter  *clientDeviceFilterDataSourceModel `tfsdk:"filter"`
+	Site    types.String                       `tfsdk:"site"`
 }
 
 type clientDeviceFilterDataSourceModel struct {
@@ -110,6 +111,10 @@ func (d *clientDevicesDataSource) Schema(_ context.Context, req datasource.Schem
 					},
 				},
 			},
+			"site": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+			},
 			"filter": schema.SingleNestedAttribute{
 				Optional: true,
 				Attributes: map[string]schema.Attribute{
@@ -152,11 +157,12 @@ func (d *clientDevicesDataSource) Read(ctx context.Context, req datasource.ReadR
 	}
 
 	// query for all devices
-	devices, err := d.client.GetClientDevices(ctx)
+	site := siteFromModel(config.Site, d.client)
+	devices, err := d.client.GetClientDevices(ctx, site)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"UDM API: Failed to Retrieve Client Device Devices",
-			fmt.Sprintf("Failed to retrieve client devices from the UDM API:\n\t%s", err.Error()),
+			fmt.Sprintf("Failed to retrieve client devices from the UDM API:\n\t%s", apiErrorDetail(err)),
 		)
 		return
 	}
@@ -165,6 +171,7 @@ func (d *clientDevicesDataSource) Read(ctx context.Context, req datasource.ReadR
 	state := clientDevicesDataSourceModel{
 		Devices: []clientDeviceDataSourceModel{},
 		Filter:  config.Filter,
+		Site:    types.StringValue(site),
 	}
 	for _, device := range devices {
 		if config.Filter != nil {
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestAccClientDevicesDataSource(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	for _, device := range []map[string]any{
+		{"mac": "00:11:22:33:44:55", "name": "printer", "fixed_ip": "192.168.1.50", "use_fixedip": true},
+		{"mac": "00:11:22:33:44:66", "name": "laptop", "use_fixedip": false},
+		{"mac": "00:11:22:33:44:77", "name": "nas", "fixed_ip": "192.168.1.60", "use_fixedip": true,
+			"local_dns_record": "nas.example.com", "local_dns_record_enabled": true},
+	} {
+		server.AddObject(udmmock.DefaultSite, udmmock.CollectionClientDevices, device)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// no filter
+			{
+				Config: providerConfig + `
+data "udm_client_devices" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.udm_client_devices.test", "devices.#", "3"),
+				),
+			},
+			// filter by fixed IP status
+			{
+				Config: providerConfig + `
+data "udm_client_devices" "test" {
+  filter = {
+    use_fixed_ip = true
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.udm_client_devices.test", "devices.#", "2"),
+					resource.TestCheckResourceAttr("data.udm_client_devices.test", "devices.0.name", "printer"),
+					resource.TestCheckResourceAttr("data.udm_client_devices.test", "devices.1.name", "nas"),
+				),
+			},
+			// filter by MAC address
+			{
+				Config: providerConfig + `
+data "udm_client_devices" "test" {
+  filter = {
+    mac_address = "00:11:22:33:44:77"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.udm_client_devices.test", "devices.#", "1"),
+					resource.TestCheckResourceAttr("data.udm_client_devices.test", "devices.0.local_dns_record",
+						"nas.example.com"),
+					resource.TestCheckResourceAttr("data.udm_client_devices.test", "devices.0.local_dns_record_enabled",
+						"true"),
+				),
+			},
+			// no matches
+			{
+				Config: providerConfig + `
+data "udm_client_devices" "test" {
+  filter = {
+    name = "missing"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.udm_client_devices.test", "devices.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -2,12 +2,15 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
 )
@@ -17,6 +20,7 @@ var (
 	_ resource.Resource                = &clientDeviceResource{}
 	_ resource.ResourceWithConfigure   = &clientDeviceResource{}
 	_ resource.ResourceWithImportState = &clientDeviceResource{}
+	_ resource.ResourceWithModifyPlan  = &clientDeviceResource{}
 )
 
 // NewClientDeviceResource is a helper function to simplify the provider implementation.
@@ -36,6 +40,7 @@ type clientDeviceResourceModel struct {
 	Name                  types.String `tfsdk:"name"`
 	LocalDNSRecord        types.String `tfsdk:"local_dns_record"`
 	LocalDNSRecordEnabled types.Bool   `tfsdk:"local_dns_record_enabled"`
+	Site                  types.String `tfsdk:"site"`
 	UseFixedIP            types.Bool   `tfsdk:"use_fixed_ip"`
 }
 
@@ -69,6 +74,9 @@ func (r *clientDeviceResource) Schema(_ context.Context, _ resource.SchemaReques
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"fixed_ip": schema.StringAttribute{
 				Computed: true,
@@ -76,6 +84,9 @@ func (r *clientDeviceResource) Schema(_ context.Context, _ resource.SchemaReques
 			},
 			"mac_address": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Computed: true,
@@ -89,6 +100,10 @@ func (r *clientDeviceResource) Schema(_ context.Context, _ resource.SchemaReques
 				Computed: true,
 				Optional: true,
 			},
+			"site": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+			},
 			"use_fixed_ip": schema.BoolAttribute{
 				Computed: true,
 				Optional: true,
@@ -127,12 +142,13 @@ func (r *clientDeviceResource) Create(ctx context.Context, req resource.CreateRe
 		device.UseFixedIP = plan.UseFixedIP.ValueBool()
 	}
 
-	// create the record
-	createdDevice, err := r.client.CreateClientDevice(ctx, device)
+	// create the device
+	site := siteFromModel(plan.Site, r.client)
+	createdDevice, err := r.client.CreateClientDevice(ctx, site, device)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"UDM API: Failed to Create Client Device",
-			fmt.Sprintf("Failed to create client device using the UDM API:\n\t%s", err.Error()),
+			fmt.Sprintf("Failed to create client device using the UDM API:\n\t%s", apiErrorDetail(err)),
 		)
 		return
 	}
@@ -144,6 +160,7 @@ func (r *clientDeviceResource) Create(ctx context.Context, req resource.CreateRe
 	plan.LocalDNSRecord = types.StringValue(createdDevice.LocalDNSRecord)
 	plan.LocalDNSRecordEnabled = types.BoolValue(createdDevice.LocalDNSRecordEnabled)
 	plan.Name = types.StringValue(createdDevice.Name)
+	plan.Site = types.StringValue(site)
 	plan.UseFixedIP = types.BoolValue(createdDevice.UseFixedIP)
 
 	// save state with the populated data
@@ -164,13 +181,23 @@ func (r *clientDeviceResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	// refresh value from the API
-	device, err := r.client.GetClientDevice(ctx, state.ID.ValueString())
+	// refresh value from the API - resources created before sites could be chosen live in the default site
+	site := siteFromModel(state.Site, r.client)
+	device, err := r.client.GetClientDevice(ctx, site, state.ID.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		// the client device was removed outside of Terraform so drop it from the state to have it recreated
+		tflog.Warn(ctx, "client device no longer exists - removing from state", map[string]any{
+			"id":   state.ID.ValueString(),
+			"site": site,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"UDM API: Failed to Retrieve Client Device",
 			fmt.Sprintf("Failed to retrieve the client device with the ID '%s': %s",
-				state.ID.ValueString(), err.Error()),
+				state.ID.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -181,6 +208,7 @@ func (r *clientDeviceResource) Read(ctx context.Context, req resource.ReadReques
 	state.LocalDNSRecord = types.StringValue(device.LocalDNSRecord)
 	state.LocalDNSRecordEnabled = types.BoolValue(device.LocalDNSRecordEnabled)
 	state.Name = types.StringValue(device.Name)
+	state.Site = types.StringValue(site)
 	state.UseFixedIP = types.BoolValue(device.UseFixedIP)
 
 	// set the refreshed state
@@ -193,12 +221,99 @@ func (r *clientDeviceResource) Read(ctx context.Context, req resource.ReadReques
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *clientDeviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan clientDeviceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// start from the current device so fields not managed by Terraform are preserved
+	site := siteFromModel(plan.Site, r.client)
+	device, err := r.client.GetClientDevice(ctx, site, plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve Client Device",
+			fmt.Sprintf("Failed to retrieve the client device with the ID '%s': %s",
+				plan.ID.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// generate API request body from plan
+	device.HardwareAddress = plan.HardwareAddress.ValueString()
+	if !plan.FixedIP.IsNull() && !plan.FixedIP.IsUnknown() {
+		device.FixedIP = plan.FixedIP.ValueString()
+	}
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		device.Name = plan.Name.ValueString()
+	}
+	if !plan.LocalDNSRecord.IsNull() && !plan.LocalDNSRecord.IsUnknown() {
+		device.LocalDNSRecord = plan.LocalDNSRecord.ValueString()
+	}
+	if !plan.LocalDNSRecordEnabled.IsNull() && !plan.LocalDNSRecordEnabled.IsUnknown() {
+		device.LocalDNSRecordEnabled = plan.LocalDNSRecordEnabled.ValueBool()
+	}
+	if !plan.UseFixedIP.IsNull() && !plan.UseFixedIP.IsUnknown() {
+		device.UseFixedIP = plan.UseFixedIP.ValueBool()
+	}
+
+	// update the device
+	updatedDevice, err := r.client.UpdateClientDevice(ctx, site, plan.ID.ValueString(), device)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Update Client Device",
+			fmt.Sprintf("Failed to update client device using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	plan.ID = types.StringValue(updatedDevice.ID)
+	plan.HardwareAddress = types.StringValue(updatedDevice.HardwareAddress)
+	plan.FixedIP = types.StringValue(updatedDevice.FixedIP)
+	plan.LocalDNSRecord = types.StringValue(updatedDevice.LocalDNSRecord)
+	plan.LocalDNSRecordEnabled = types.BoolValue(updatedDevice.LocalDNSRecordEnabled)
+	plan.Name = types.StringValue(updatedDevice.Name)
+	plan.Site = types.StringValue(site)
+	plan.UseFixedIP = types.BoolValue(updatedDevice.UseFixedIP)
+
+	// save state with the populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *clientDeviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// retrieve the current state
+	var state clientDeviceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// forget the device
+	if err := r.client.DeleteClientDevice(ctx, siteFromModel(state.Site, r.client),
+		state.HardwareAddress.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Delete Client Device",
+			fmt.Sprintf("Failed to delete client device using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+}
+
+// ModifyPlan sets the planned site of the device and replaces the device when it is moved to another site.
+func (r *clientDeviceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifySitePlan(ctx, r.client, req, resp)
 }
 
+// ImportState imports a device by its ID or by its site and ID (ie: "site/id").
 func (r *clientDeviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithSite(ctx, r.client, req, resp)
 }
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestAccClientDeviceResource(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckCollectionEmpty(server, udmmock.DefaultSite,
+			udmmock.CollectionClientDevices),
+		Steps: []resource.TestStep{
+			// create and read
+			{
+				Config: providerConfig + `
+resource "udm_client_device" "test" {
+  mac_address  = "00:11:22:33:44:55"
+  name         = "printer"
+  fixed_ip     = "192.168.1.50"
+  use_fixed_ip = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("udm_client_device.test", "id"),
+					resource.TestCheckResourceAttr("udm_client_device.test", "mac_address", "00:11:22:33:44:55"),
+					resource.TestCheckResourceAttr("udm_client_device.test", "name", "printer"),
+					resource.TestCheckResourceAttr("udm_client_device.test", "fixed_ip", "192.168.1.50"),
+					resource.TestCheckResourceAttr("udm_client_device.test", "use_fixed_ip", "true"),
+					resource.TestCheckResourceAttr("udm_client_device.test", "local_dns_record_enabled", "false"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionClientDevices,
+						"udm_client_device.test", "fixed_ip", "192.168.1.50"),
+				),
+			},
+			// import
+			{
+				ResourceName:      "udm_client_device.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// update and read
+			{
+				Config: providerConfig + `
+resource "udm_client_device" "test" {
+  mac_address              = "00:11:22:33:44:55"
+  name                     = "office-printer"
+  fixed_ip                 = "192.168.1.51"
+  use_fixed_ip             = true
+  local_dns_record         = "printer.example.com"
+  local_dns_record_enabled = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_client_device.test", "name", "office-printer"),
+					resource.TestCheckResourceAttr("udm_client_device.test", "fixed_ip", "192.168.1.51"),
+					resource.TestCheckResourceAttr("udm_client_device.test", "local_dns_record",
+						"printer.example.com"),
+					resource.TestCheckResourceAttr("udm_client_device.test", "local_dns_record_enabled", "true"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionClientDevices,
+						"udm_client_device.test", "name", "office-printer"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionClientDevices,
+						"udm_client_device.test", "fixed_ip", "192.168.1.51"),
+				),
+			},
+			// disable the fixed IP
+			{
+				Config: providerConfig + `
+resource "udm_client_device" "test" {
+  mac_address              = "00:11:22:33:44:55"
+  name                     = "office-printer"
+  fixed_ip                 = "192.168.1.51"
+  use_fixed_ip             = false
+  local_dns_record         = "printer.example.com"
+  local_dns_record_enabled = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_client_device.test", "use_fixed_ip", "false"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionClientDevices,
+						"udm_client_device.test", "use_fixedip", false),
+				),
+			},
+			// recreate after the device was forgotten outside of Terraform
+			{
+				PreConfig: func() {
+					testAccDeleteAllObjects(server, udmmock.DefaultSite, udmmock.CollectionClientDevices)
+				},
+				Config: providerConfig + `
+resource "udm_client_device" "test" {
+  mac_address              = "00:11:22:33:44:55"
+  name                     = "office-printer"
+  fixed_ip                 = "192.168.1.51"
+  use_fixed_ip             = false
+  local_dns_record         = "printer.example.com"
+  local_dns_record_enabled = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionClientDevices,
+						"udm_client_device.test", "mac", "00:11:22:33:44:55"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultCredentialsProfile = "default" // Name of the profile used when none is configured
+)
+
+var (
+	errProfileNotFound = errors.New("profile not found") // Returned when a credentials file lacks the profile
+)
+
+// credentialsProfile holds the key/value pairs of a single profile in a credentials file.
+type credentialsProfile map[string]string
+
+// defaultCredentialsFile returns the path of the credentials file used when none is configured.
+func defaultCredentialsFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".udm", "credentials")
+}
+
+// loadCredentialsProfile reads the named profile from a credentials file.
+//
+// Files with a '.yaml' or '.yml' extension are parsed as YAML and all other files are parsed as INI.
+func loadCredentialsProfile(filename, profile string) (credentialsProfile, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		return loadYAMLCredentialsProfile(filename, profile)
+	default:
+		return loadINICredentialsProfile(filename, profile)
+	}
+}
+
+// loadINICredentialsProfile reads the named profile from an INI-style credentials file.
+//
+// The file format is similar to the AWS shared credentials file:
+//
+//	[default]
+//	hostname = 192.168.1.1
+//	username = terraform
+//	password = secret
+//
+//	[branch-office]
+//	hostname = 10.10.0.1
+//	api_key  = xxxxxxxx
+//
+// Section headers may also be written as "[profile name]".  Lines starting with '#' or ';' are comments and values may
+// optionally be wrapped in quotes.
+func loadINICredentialsProfile(filename, profile string) (credentialsProfile, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var section string
+	found := false
+	values := credentialsProfile{}
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		// section header
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%s:%d: malformed profile header: %s", filename, lineNum, line)
+			}
+			section = strings.TrimSpace(strings.TrimPrefix(strings.Trim(line, "[]"), "profile "))
+			if section == profile {
+				found = true
+			}
+			continue
+		}
+
+		// key/value pair
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected 'key = value' but found: %s", filename, lineNum, line)
+		}
+		if section != profile {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: no '%s' profile exists in credentials file '%s'", errProfileNotFound, profile,
+			filename)
+	}
+	return values, nil
+}
+
+// loadYAMLCredentialsProfile reads the named profile from a YAML credentials file.
+//
+// The file holds a mapping of profile names to mappings of keys to scalar values:
+//
+//	default:
+//	  hostname: 192.168.1.1
+//	  username: terraform
+//	  password: secret
+//
+//	branch-office:
+//	  hostname: 10.10.0.1
+//	  api_key: xxxxxxxx
+func loadYAMLCredentialsProfile(filename, profile string) (credentialsProfile, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	// values are decoded as nodes rather than native types so that scalars keep their exact text (eg: a password of
+	// 0123456 must not be read as an octal number)
+	var profiles map[string]map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	section, ok := profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("%w: no '%s' profile exists in credentials file '%s'", errProfileNotFound, profile,
+			filename)
+	}
+
+	values := credentialsProfile{}
+	for key, node := range section {
+		if node.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("%s:%d: value of '%s' in the '%s' profile must be a scalar", filename, node.Line,
+				key, profile)
+		}
+		if node.Tag == "!!null" {
+			continue
+		}
+		values[strings.ToLower(key)] = node.Value
+	}
+	return values, nil
+}
+
+// resolveString returns the value of a string provider attribute if it is set, falling back to the given environment
+// variable and then to the given key of the credentials profile.
+func resolveString(value types.String, envVar string, profile credentialsProfile, key string) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+	if v := os.Getenv(envVar); v != "" {
+		return v
+	}
+	return profile[key]
+}
+
+// resolveBool returns the value of a boolean provider attribute if it is set, falling back to the given environment
+// variable and then to the given key of the credentials profile.
+func resolveBool(value types.Bool, envVar string, profile credentialsProfile, key string) (bool, error) {
+	if !value.IsNull() {
+		return value.ValueBool(), nil
+	}
+	if v := os.Getenv(envVar); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, fmt.Errorf("invalid value for the %s environment variable: %s", envVar, v)
+		}
+		return b, nil
+	}
+	if v, ok := profile[key]; ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, fmt.Errorf("invalid value for '%s' in the credentials file: %s", key, v)
+		}
+		return b, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeCredentialsFile writes a credentials file with the given name and contents to a temporary directory and returns
+// its path.
+func writeCredentialsFile(t *testing.T, name, contents string) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(filename, []byte(contents), 0o600); err != nil {
+		t.Fatalf("failed to write credentials file: %s", err)
+	}
+	return filename
+}
+
+func TestLoadCredentialsProfile(t *testing.T) {
+	const ini = `
+# comment
+[default]
+hostname = 192.168.1.1
+username = terraform
+password = "secret"
+
+[profile branch-office]
+hostname = 10.10.0.1
+api_key  = xxxxxxxx
+ignore_untrusted_ssl_certificate = true
+`
+	const yml = `
+# comment
+default:
+  hostname: 192.168.1.1
+  username: terraform
+  password: "secret"
+
+branch-office:
+  hostname: 10.10.0.1
+  api_key: xxxxxxxx
+  ignore_untrusted_ssl_certificate: true
+  site:
+`
+	want := map[string]credentialsProfile{
+		"default": {
+			"hostname": "192.168.1.1",
+			"username": "terraform",
+			"password": "secret",
+		},
+		"branch-office": {
+			"hostname":                         "10.10.0.1",
+			"api_key":                          "xxxxxxxx",
+			"ignore_untrusted_ssl_certificate": "true",
+		},
+	}
+
+	for _, name := range []string{"credentials", "credentials.ini", "credentials.yaml", "credentials.YML"} {
+		contents := ini
+		if ext := filepath.Ext(name); ext == ".yaml" || ext == ".YML" {
+			contents = yml
+		}
+		filename := writeCredentialsFile(t, name, contents)
+		for profile, values := range want {
+			got, err := loadCredentialsProfile(filename, profile)
+			if err != nil {
+				t.Fatalf("loadCredentialsProfile(%s, %s) returned an error: %s", name, profile, err)
+			}
+			if !reflect.DeepEqual(got, values) {
+				t.Errorf("loadCredentialsProfile(%s, %s) = %v, want %v", name, profile, got, values)
+			}
+		}
+		if _, err := loadCredentialsProfile(filename, "missing"); !errors.Is(err, errProfileNotFound) {
+			t.Errorf("loadCredentialsProfile(%s, missing) returned %v, want %v", name, err, errProfileNotFound)
+		}
+	}
+}
+
+func TestLoadCredentialsProfileYAMLScalars(t *testing.T) {
+	// values which look like numbers or booleans must be read exactly as written
+	filename := writeCredentialsFile(t, "credentials.yaml", `
+default:
+  password: 0123456
+  api_key: 1e10
+  site: 0x10
+  mfa_token: 000123
+  totp_secret: yes
+  ignore_untrusted_ssl_certificate: true
+  username: ~
+`)
+	want := credentialsProfile{
+		"password":                         "0123456",
+		"api_key":                          "1e10",
+		"site":                             "0x10",
+		"mfa_token":                        "000123",
+		"totp_secret":                      "yes",
+		"ignore_untrusted_ssl_certificate": "true",
+	}
+	got, err := loadCredentialsProfile(filename, "default")
+	if err != nil {
+		t.Fatalf("loadCredentialsProfile() returned an error: %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("loadCredentialsProfile() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadCredentialsProfileInvalid(t *testing.T) {
+	tests := map[string]string{
+		"credentials":      "[default\nhostname = 192.168.1.1\n",
+		"credentials.ini":  "[default]\nhostname\n",
+		"credentials.yaml": "default: [192.168.1.1]\n",
+		"credentials.yml":  "default:\n  hostname:\n    - 192.168.1.1\n",
+	}
+	for name, contents := range tests {
+		filename := writeCredentialsFile(t, name, contents)
+		if _, err := loadCredentialsProfile(filename, "default"); err == nil {
+			t.Errorf("loadCredentialsProfile(%s) with invalid contents did not return an error", name)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
+)
+
+// apiErrorDetail formats an error returned by the UDM API client for use in the detail of a diagnostic.
+//
+// When the error wraps an *api.Error, the HTTP status and the error fields returned by the UDM are listed below the
+// error message so that validation, authentication and server failures can be told apart.
+func apiErrorDetail(err error) string {
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	var b strings.Builder
+	b.WriteString(err.Error())
+	fmt.Fprintf(&b, "\n\n\tHTTP Status: %d\n\tRequest: %s %s", apiErr.StatusCode, apiErr.Method, apiErr.Endpoint)
+	if apiErr.ReturnCode != "" {
+		fmt.Fprintf(&b, "\n\tReturn Code: %s", apiErr.ReturnCode)
+	}
+	if apiErr.Code != "" {
+		fmt.Fprintf(&b, "\n\tCode: %s", apiErr.Code)
+	}
+	if apiErr.ErrorCode != 0 {
+		fmt.Fprintf(&b, "\n\tError Code: %d", apiErr.ErrorCode)
+	}
+	if apiErr.Message != "" {
+		fmt.Fprintf(&b, "\n\tMessage: %s", apiErr.Message)
+	}
+	if apiErr.Details != nil {
+		fmt.Fprintf(&b, "\n\tDetails: %v", apiErr.Details)
+	}
+	return b.String()
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
+)
+
+// requireFeature returns an error diagnostic if the network application is too old for a feature used by a resource or
+// data source, so that users get a clear error at plan time rather than an opaque failure from the UDM.
+func requireFeature(client *api.Client, feature api.Feature) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// nothing can be checked until the provider has been configured
+	if client == nil {
+		return diags
+	}
+	if err := client.RequireFeature(feature); err != nil {
+		diags.AddError(
+			"Unsupported Network Application Version",
+			err.Error()+".  Upgrade the network application on the UDM to manage them.",
+		)
+	}
+	return diags
+}
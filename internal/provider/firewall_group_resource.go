@@ -0,0 +1,343 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &firewallGroupResource{}
+	_ resource.ResourceWithConfigure      = &firewallGroupResource{}
+	_ resource.ResourceWithImportState    = &firewallGroupResource{}
+	_ resource.ResourceWithModifyPlan     = &firewallGroupResource{}
+	_ resource.ResourceWithValidateConfig = &firewallGroupResource{}
+)
+
+// NewFirewallGroupResource is a helper function to simplify the provider implementation.
+func NewFirewallGroupResource() resource.Resource {
+	return &firewallGroupResource{}
+}
+
+// firewallGroupResource is the resource implementation.
+type firewallGroupResource struct {
+	client *api.Client
+}
+
+type firewallGroupResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Members types.Set    `tfsdk:"members"`
+	Name    types.String `tfsdk:"name"`
+	Site    types.String `tfsdk:"site"`
+	Type    types.String `tfsdk:"type"`
+}
+
+func (r *firewallGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *firewallGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_group"
+}
+
+// Schema defines the schema for the resource.
+func (r *firewallGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"members": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"site": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"type": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("address-group", "ipv6-address-group", "port-group"),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the members of the group match its type.
+func (r *firewallGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config firewallGroupResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || config.Type.IsUnknown() || config.Members.IsUnknown() {
+		return
+	}
+
+	groupType := config.Type.ValueString()
+	for _, element := range config.Members.Elements() {
+		member, ok := element.(types.String)
+		if !ok || member.IsNull() || member.IsUnknown() {
+			continue
+		}
+		var err error
+		switch groupType {
+		case "address-group":
+			err = validateAddressRange(member.ValueString(), false)
+		case "ipv6-address-group":
+			err = validateAddressRange(member.ValueString(), true)
+		case "port-group":
+			err = validatePortRange(member.ValueString())
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("members"),
+				"Invalid Firewall Group Member",
+				fmt.Sprintf("The member '%s' is not valid for a group with a type of '%s': %s", member.ValueString(),
+					groupType, err),
+			)
+		}
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *firewallGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan firewallGroupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// generate API request body from plan
+	group := api.FirewallGroup{
+		GroupMembers: []string{},
+		GroupType:    plan.Type.ValueString(),
+		Name:         plan.Name.ValueString(),
+	}
+	resp.Diagnostics.Append(plan.Members.ElementsAs(ctx, &group.GroupMembers, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the group
+	site := siteFromModel(plan.Site, r.client)
+	createdGroup, err := r.client.CreateFirewallGroup(ctx, site, group)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Create Firewall Group",
+			fmt.Sprintf("Failed to create firewall group using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	plan.ID = types.StringValue(createdGroup.ID)
+	plan.Members, diags = types.SetValueFrom(ctx, types.StringType, nonNilStrings(createdGroup.GroupMembers))
+	resp.Diagnostics.Append(diags...)
+	plan.Name = types.StringValue(createdGroup.Name)
+	plan.Site = types.StringValue(site)
+	plan.Type = types.StringValue(createdGroup.GroupType)
+
+	// save state with the populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *firewallGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// retrieve the current state
+	var state firewallGroupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// refresh value from the API
+	site := siteFromModel(state.Site, r.client)
+	group, err := r.client.GetFirewallGroup(ctx, site, state.ID.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		// the group was removed outside of Terraform so drop it from the state to have it recreated
+		tflog.Warn(ctx, "firewall group no longer exists - removing from state", map[string]any{
+			"id":   state.ID.ValueString(),
+			"site": site,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve Firewall Group",
+			fmt.Sprintf("Failed to retrieve the firewall group with the ID '%s': %s",
+				state.ID.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// update the state
+	state.Members, diags = types.SetValueFrom(ctx, types.StringType, nonNilStrings(group.GroupMembers))
+	resp.Diagnostics.Append(diags...)
+	state.Name = types.StringValue(group.Name)
+	state.Site = types.StringValue(site)
+	state.Type = types.StringValue(group.GroupType)
+
+	// set the refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *firewallGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan firewallGroupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// generate API request body from plan
+	group := api.FirewallGroup{
+		GroupMembers: []string{},
+		GroupType:    plan.Type.ValueString(),
+		ID:           plan.ID.ValueString(),
+		Name:         plan.Name.ValueString(),
+	}
+	resp.Diagnostics.Append(plan.Members.ElementsAs(ctx, &group.GroupMembers, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the group
+	site := siteFromModel(plan.Site, r.client)
+	updatedGroup, err := r.client.UpdateFirewallGroup(ctx, site, plan.ID.ValueString(), group)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Update Firewall Group",
+			fmt.Sprintf("Failed to update firewall group using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	plan.Members, diags = types.SetValueFrom(ctx, types.StringType, nonNilStrings(updatedGroup.GroupMembers))
+	resp.Diagnostics.Append(diags...)
+	plan.Name = types.StringValue(updatedGroup.Name)
+	plan.Site = types.StringValue(site)
+	plan.Type = types.StringValue(updatedGroup.GroupType)
+
+	// save state with the populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *firewallGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// retrieve the current state
+	var state firewallGroupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the group
+	if err := r.client.DeleteFirewallGroup(ctx, siteFromModel(state.Site, r.client), state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Delete Firewall Group",
+			fmt.Sprintf("Failed to delete firewall group using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+}
+
+// ModifyPlan sets the planned site of the group and replaces the group when it is moved to another site.
+func (r *firewallGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifySitePlan(ctx, r.client, req, resp)
+}
+
+// ImportState imports a group by its ID or name, optionally prefixed by its site (ie: "site/id" or "site/name").
+func (r *firewallGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	site, id, ok := parseImportID(r.client, req, resp)
+	if !ok {
+		return
+	}
+
+	// look up the ID of the group if it was imported by its name
+	if !isObjectID(id) {
+		groups, err := r.client.GetFirewallGroups(ctx, site)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"UDM API: Failed to Retrieve Firewall Groups",
+				fmt.Sprintf("Failed to retrieve firewall groups from the UDM API:\n\t%s", apiErrorDetail(err)),
+			)
+			return
+		}
+		name := id
+		id = ""
+		for _, group := range groups {
+			if group.Name == name {
+				id = group.ID
+				break
+			}
+		}
+		if id == "" {
+			resp.Diagnostics.AddError(
+				"Firewall Group Not Found",
+				fmt.Sprintf("No firewall group named '%s' exists in the site '%s'.", name, site),
+			)
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), site)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestAccFirewallGroupResource(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckCollectionEmpty(server, udmmock.DefaultSite, udmmock.CollectionFirewallGroups),
+			testAccCheckCollectionEmpty(server, udmmock.DefaultSite, udmmock.CollectionFirewallRules),
+		),
+		Steps: []resource.TestStep{
+			// create and read, with a rule referencing the group so it must be destroyed first
+			{
+				Config: providerConfig + `
+variable "blocklist" {
+  default = ["203.0.113.0/24", "198.51.100.7", "192.0.2.10-192.0.2.20"]
+}
+
+resource "udm_firewall_group" "test" {
+  name    = "Blocklist"
+  type    = "address-group"
+  members = var.blocklist
+}
+
+resource "udm_firewall_rule" "test" {
+  name                   = "Drop the blocklist"
+  ruleset                = "WAN_IN"
+  rule_index             = 2000
+  action                 = "drop"
+  src_firewall_group_ids = [udm_firewall_group.test.id]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("udm_firewall_group.test", "id"),
+					resource.TestCheckResourceAttr("udm_firewall_group.test", "members.#", "3"),
+					resource.TestCheckTypeSetElemAttr("udm_firewall_group.test", "members.*", "198.51.100.7"),
+					resource.TestCheckTypeSetElemAttrPair("udm_firewall_rule.test", "src_firewall_group_ids.*",
+						"udm_firewall_group.test", "id"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallGroups,
+						"udm_firewall_group.test", "group_type", "address-group"),
+				),
+			},
+			// import by name
+			{
+				ResourceName:      "udm_firewall_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return "Blocklist", nil
+				},
+			},
+			// update the members and drop the rule
+			{
+				Config: providerConfig + `
+resource "udm_firewall_group" "test" {
+  name    = "Blocklist"
+  type    = "address-group"
+  members = ["203.0.113.0/24"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_firewall_group.test", "members.#", "1"),
+					testAccCheckCollectionEmpty(server, udmmock.DefaultSite, udmmock.CollectionFirewallRules),
+				),
+			},
+			// changing the type replaces the group
+			{
+				Config: providerConfig + `
+resource "udm_firewall_group" "test" {
+  name    = "Admin ports"
+  type    = "port-group"
+  members = ["22", "8443", "10000-10100"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_firewall_group.test", "type", "port-group"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallGroups,
+						"udm_firewall_group.test", "name", "Admin ports"),
+					func(*terraform.State) error {
+						if n := len(server.Objects(udmmock.DefaultSite, udmmock.CollectionFirewallGroups)); n != 1 {
+							return fmt.Errorf("%d firewall groups exist, want 1", n)
+						}
+						return nil
+					},
+				),
+			},
+			// recreate after the group was deleted outside of Terraform
+			{
+				PreConfig: func() {
+					testAccDeleteAllObjects(server, udmmock.DefaultSite, udmmock.CollectionFirewallGroups)
+				},
+				Config: providerConfig + `
+resource "udm_firewall_group" "test" {
+  name    = "IPv6 blocklist"
+  type    = "ipv6-address-group"
+  members = ["2001:db8:1::/48", "2001:db8::1"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallGroups,
+						"udm_firewall_group.test", "group_type", "ipv6-address-group"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFirewallGroupResourceValidation(t *testing.T) {
+	_, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "udm_firewall_group" "test" {
+  name    = "Blocklist"
+  type    = "address-group"
+  members = ["203.0.113.0/24", "2001:db8::/32"]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`'2001:db8::/32' is not an IPv4 address or subnet`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_firewall_group" "test" {
+  name    = "Blocklist"
+  type    = "ipv6-address-group"
+  members = ["203.0.113.0/24"]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`is not an IPv6 address or subnet`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_firewall_group" "test" {
+  name    = "Admin ports"
+  type    = "port-group"
+  members = ["22", "70000"]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`'70000' is not a port between 1 and 65535`),
+			},
+		},
+	})
+}
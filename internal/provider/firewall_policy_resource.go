@@ -0,0 +1,641 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &firewallPolicyResource{}
+	_ resource.ResourceWithConfigure      = &firewallPolicyResource{}
+	_ resource.ResourceWithImportState    = &firewallPolicyResource{}
+	_ resource.ResourceWithModifyPlan     = &firewallPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &firewallPolicyResource{}
+)
+
+// timeOfDayPattern matches a time of day in 24-hour format (ie: "08:30").
+var timeOfDayPattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// NewFirewallPolicyResource is a helper function to simplify the provider implementation.
+func NewFirewallPolicyResource() resource.Resource {
+	return &firewallPolicyResource{}
+}
+
+// firewallPolicyResource is the resource implementation.
+type firewallPolicyResource struct {
+	client *api.Client
+}
+
+type firewallPolicyResourceModel struct {
+	ID               types.String                 `tfsdk:"id"`
+	Action           types.String                 `tfsdk:"action"`
+	ConnectionStates types.Set                    `tfsdk:"connection_states"`
+	Description      types.String                 `tfsdk:"description"`
+	Destination      *firewallPolicyEndpointModel `tfsdk:"destination"`
+	Enabled          types.Bool                   `tfsdk:"enabled"`
+	Index            types.Int32                  `tfsdk:"index"`
+	IPVersion        types.String                 `tfsdk:"ip_version"`
+	Logging          types.Bool                   `tfsdk:"logging"`
+	Name             types.String                 `tfsdk:"name"`
+	Protocol         types.String                 `tfsdk:"protocol"`
+	Schedule         *firewallPolicyScheduleModel `tfsdk:"schedule"`
+	Site             types.String                 `tfsdk:"site"`
+	Source           *firewallPolicyEndpointModel `tfsdk:"source"`
+}
+
+type firewallPolicyEndpointModel struct {
+	IPs        types.Set    `tfsdk:"ips"`
+	NetworkIDs types.Set    `tfsdk:"network_ids"`
+	Port       types.String `tfsdk:"port"`
+	ZoneID     types.String `tfsdk:"zone_id"`
+}
+
+type firewallPolicyScheduleModel struct {
+	DaysOfWeek types.Set    `tfsdk:"days_of_week"`
+	EndTime    types.String `tfsdk:"end_time"`
+	Mode       types.String `tfsdk:"mode"`
+	StartTime  types.String `tfsdk:"start_time"`
+}
+
+// applyTo copies the values set in the model to a firewall policy, leaving the fields of any unset computed values
+// untouched.
+func (m firewallPolicyResourceModel) applyTo(ctx context.Context, policy *api.FirewallPolicy) diag.Diagnostics {
+	var diags diag.Diagnostics
+	policy.Action = strings.ToUpper(m.Action.ValueString())
+	policy.Description = m.Description.ValueString()
+	policy.Name = m.Name.ValueString()
+	if m.Source != nil {
+		diags.Append(m.Source.applyTo(ctx, &policy.Source)...)
+	}
+	if m.Destination != nil {
+		diags.Append(m.Destination.applyTo(ctx, &policy.Destination)...)
+	}
+
+	// connection states are only matched when at least one of them is listed
+	if !m.ConnectionStates.IsNull() && !m.ConnectionStates.IsUnknown() {
+		policy.ConnectionStates = []string{}
+		diags.Append(m.ConnectionStates.ElementsAs(ctx, &policy.ConnectionStates, false)...)
+		for i, state := range policy.ConnectionStates {
+			policy.ConnectionStates[i] = strings.ToUpper(state)
+		}
+	}
+	policy.ConnectionStates = nonNilStrings(policy.ConnectionStates)
+	policy.ConnectionStateType = "ALL"
+	if len(policy.ConnectionStates) > 0 {
+		policy.ConnectionStateType = "CUSTOM"
+	}
+
+	// policies without a schedule are always active
+	policy.Schedule = api.FirewallPolicySchedule{Mode: "ALWAYS", RepeatOnDays: []string{}, TimeAllDay: true}
+	if m.Schedule != nil {
+		policy.Schedule.Mode = strings.ToUpper(m.Schedule.Mode.ValueString())
+		if !m.Schedule.DaysOfWeek.IsNull() && !m.Schedule.DaysOfWeek.IsUnknown() {
+			diags.Append(m.Schedule.DaysOfWeek.ElementsAs(ctx, &policy.Schedule.RepeatOnDays, false)...)
+		}
+		policy.Schedule.TimeRangeStart = m.Schedule.StartTime.ValueString()
+		policy.Schedule.TimeRangeEnd = m.Schedule.EndTime.ValueString()
+		policy.Schedule.TimeAllDay = policy.Schedule.TimeRangeStart == ""
+	}
+
+	if !m.Enabled.IsNull() && !m.Enabled.IsUnknown() {
+		policy.Enabled = m.Enabled.ValueBool()
+	}
+	if !m.Index.IsNull() && !m.Index.IsUnknown() {
+		policy.Index = int(m.Index.ValueInt32())
+	}
+	if !m.IPVersion.IsNull() && !m.IPVersion.IsUnknown() {
+		policy.IPVersion = strings.ToUpper(m.IPVersion.ValueString())
+	}
+	if !m.Logging.IsNull() && !m.Logging.IsUnknown() {
+		policy.Logging = m.Logging.ValueBool()
+	}
+	if !m.Protocol.IsNull() && !m.Protocol.IsUnknown() {
+		policy.Protocol = m.Protocol.ValueString()
+	}
+	return diags
+}
+
+// readFrom sets the model to the values of a firewall policy.
+func (m *firewallPolicyResourceModel) readFrom(ctx context.Context, policy api.FirewallPolicy) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	m.ID = types.StringValue(policy.ID)
+	m.Action = types.StringValue(strings.ToLower(policy.Action))
+	m.Description = optionalString(policy.Description)
+	m.Enabled = types.BoolValue(policy.Enabled)
+	m.Index = types.Int32Value(int32(policy.Index))
+	m.IPVersion = types.StringValue(strings.ToLower(policy.IPVersion))
+	m.Logging = types.BoolValue(policy.Logging)
+	m.Name = types.StringValue(policy.Name)
+	m.Protocol = types.StringValue(policy.Protocol)
+	m.Source = &firewallPolicyEndpointModel{}
+	diags.Append(m.Source.readFrom(ctx, policy.Source)...)
+	m.Destination = &firewallPolicyEndpointModel{}
+	diags.Append(m.Destination.readFrom(ctx, policy.Destination)...)
+
+	states := []string{}
+	if policy.ConnectionStateType == "CUSTOM" {
+		for _, state := range policy.ConnectionStates {
+			states = append(states, strings.ToLower(state))
+		}
+	}
+	m.ConnectionStates, d = types.SetValueFrom(ctx, types.StringType, states)
+	diags.Append(d...)
+
+	m.Schedule = nil
+	if policy.Schedule.Mode != "" && policy.Schedule.Mode != "ALWAYS" {
+		m.Schedule = &firewallPolicyScheduleModel{
+			DaysOfWeek: types.SetNull(types.StringType),
+			Mode:       types.StringValue(strings.ToLower(policy.Schedule.Mode)),
+			EndTime:    types.StringNull(),
+			StartTime:  types.StringNull(),
+		}
+		if len(policy.Schedule.RepeatOnDays) > 0 {
+			m.Schedule.DaysOfWeek, d = types.SetValueFrom(ctx, types.StringType, policy.Schedule.RepeatOnDays)
+			diags.Append(d...)
+		}
+		if !policy.Schedule.TimeAllDay {
+			m.Schedule.StartTime = optionalString(policy.Schedule.TimeRangeStart)
+			m.Schedule.EndTime = optionalString(policy.Schedule.TimeRangeEnd)
+		}
+	}
+	return diags
+}
+
+// applyTo copies the values set in the model to the source or destination of a firewall policy.
+func (m firewallPolicyEndpointModel) applyTo(ctx context.Context, endpoint *api.FirewallPolicyEndpoint) diag.Diagnostics {
+	var diags diag.Diagnostics
+	endpoint.ZoneID = m.ZoneID.ValueString()
+	endpoint.IPs = []string{}
+	if !m.IPs.IsNull() && !m.IPs.IsUnknown() {
+		diags.Append(m.IPs.ElementsAs(ctx, &endpoint.IPs, false)...)
+	}
+	endpoint.NetworkIDs = []string{}
+	if !m.NetworkIDs.IsNull() && !m.NetworkIDs.IsUnknown() {
+		diags.Append(m.NetworkIDs.ElementsAs(ctx, &endpoint.NetworkIDs, false)...)
+	}
+
+	// the UDM matches either addresses, networks or anything in the zone, which it needs to be told about
+	switch {
+	case len(endpoint.IPs) > 0:
+		endpoint.MatchingTarget = "IP"
+	case len(endpoint.NetworkIDs) > 0:
+		endpoint.MatchingTarget = "NETWORK"
+	default:
+		endpoint.MatchingTarget = "ANY"
+	}
+	endpoint.Port = m.Port.ValueString()
+	endpoint.PortMatchingType = "ANY"
+	if endpoint.Port != "" {
+		endpoint.PortMatchingType = "SPECIFIC"
+	}
+	return diags
+}
+
+// readFrom sets the model to the values of the source or destination of a firewall policy.
+func (m *firewallPolicyEndpointModel) readFrom(ctx context.Context, endpoint api.FirewallPolicyEndpoint) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	m.ZoneID = types.StringValue(endpoint.ZoneID)
+	m.IPs = types.SetNull(types.StringType)
+	if endpoint.MatchingTarget == "IP" && len(endpoint.IPs) > 0 {
+		m.IPs, d = types.SetValueFrom(ctx, types.StringType, endpoint.IPs)
+		diags.Append(d...)
+	}
+	m.NetworkIDs = types.SetNull(types.StringType)
+	if endpoint.MatchingTarget == "NETWORK" && len(endpoint.NetworkIDs) > 0 {
+		m.NetworkIDs, d = types.SetValueFrom(ctx, types.StringType, endpoint.NetworkIDs)
+		diags.Append(d...)
+	}
+	m.Port = types.StringNull()
+	if endpoint.PortMatchingType == "SPECIFIC" {
+		m.Port = optionalString(endpoint.Port)
+	}
+	return diags
+}
+
+func (r *firewallPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *firewallPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_policy"
+}
+
+// firewallPolicyEndpointSchema returns the schema of the source or destination of a policy.
+func firewallPolicyEndpointSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Required: true,
+		Attributes: map[string]schema.Attribute{
+			"ips": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.Any(ipAddressValidator{}, cidrValidator{})),
+					setvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("network_ids")),
+				},
+			},
+			"network_ids": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"port": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					portsValidator{},
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required: true,
+			},
+		},
+	}
+}
+
+// Schema defines the schema for the resource.
+func (r *firewallPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"action": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("allow", "block", "reject"),
+				},
+			},
+			"connection_states": schema.SetAttribute{
+				Computed:    true,
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf("established", "invalid", "new", "related")),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"destination": firewallPolicyEndpointSchema(),
+			"enabled": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			// policies between the same zones are applied in order of their index, with new policies placed after the
+			// existing ones unless an index is given
+			"index": schema.Int32Attribute{
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip_version": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("both", "ipv4", "ipv6"),
+				},
+			},
+			"logging": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"protocol": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("all", "tcp", "udp", "tcp_udp", "icmp", "icmpv6", "ah", "esp", "gre", "igmp",
+						"sctp", "vrrp"),
+				},
+			},
+			"schedule": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"days_of_week": schema.SetAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(
+								stringvalidator.OneOf("sun", "mon", "tue", "wed", "thu", "fri", "sat"),
+							),
+						},
+					},
+					"end_time": schema.StringAttribute{
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(timeOfDayPattern, "must be a time of day in HH:MM format"),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("start_time")),
+						},
+					},
+					"mode": schema.StringAttribute{
+						Required: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("every_day", "every_week"),
+						},
+					},
+					"start_time": schema.StringAttribute{
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(timeOfDayPattern, "must be a time of day in HH:MM format"),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("end_time")),
+						},
+					},
+				},
+			},
+			"site": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"source": firewallPolicyEndpointSchema(),
+		},
+	}
+}
+
+// ValidateConfig checks that ports are only matched for TCP and UDP, that addresses belong to the IP version of the
+// policy and that weekly schedules say which days they apply to.
+func (r *firewallPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config firewallPolicyResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoints := []struct {
+		name  string
+		value *firewallPolicyEndpointModel
+	}{
+		{name: "source", value: config.Source},
+		{name: "destination", value: config.Destination},
+	}
+	if !config.Protocol.IsUnknown() {
+		switch config.Protocol.ValueString() {
+		case "tcp", "udp", "tcp_udp":
+		default:
+			for _, endpoint := range endpoints {
+				if endpoint.value != nil && !endpoint.value.Port.IsNull() {
+					resp.Diagnostics.AddAttributeError(
+						path.Root(endpoint.name).AtName("port"),
+						"Ports Require TCP or UDP",
+						"Ports can only be matched by policies with a protocol of 'tcp', 'udp' or 'tcp_udp'.",
+					)
+				}
+			}
+		}
+	}
+
+	// malformed addresses are reported by the validators
+	if ipVersion := config.IPVersion.ValueString(); ipVersion == "ipv4" || ipVersion == "ipv6" {
+		for _, endpoint := range endpoints {
+			if endpoint.value == nil || endpoint.value.IPs.IsNull() || endpoint.value.IPs.IsUnknown() {
+				continue
+			}
+			for _, element := range endpoint.value.IPs.Elements() {
+				ip, ok := element.(types.String)
+				if !ok || ip.IsNull() || ip.IsUnknown() {
+					continue
+				}
+				prefix, err := parseAddressOrPrefix(ip.ValueString())
+				if err == nil && prefix.Addr().Is6() != (ipVersion == "ipv6") {
+					resp.Diagnostics.AddAttributeError(
+						path.Root(endpoint.name).AtName("ips"),
+						"Address Family Mismatch",
+						fmt.Sprintf("The address %s does not belong to the IP version of the policy (%s).",
+							ip.ValueString(), ipVersion),
+					)
+				}
+			}
+		}
+	}
+
+	if config.Schedule != nil && config.Schedule.Mode.ValueString() == "every_week" && config.Schedule.DaysOfWeek.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schedule").AtName("days_of_week"),
+			"Missing Days of Week",
+			"The days of the week are required for schedules with a mode of 'every_week'.",
+		)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *firewallPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan firewallPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// generate API request body from plan - new policies are enabled and match all protocols of both IP versions
+	// unless stated otherwise
+	policy := api.FirewallPolicy{
+		Enabled:   true,
+		IPVersion: "BOTH",
+		Protocol:  "all",
+	}
+	resp.Diagnostics.Append(plan.applyTo(ctx, &policy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the policy
+	site := siteFromModel(plan.Site, r.client)
+	createdPolicy, err := r.client.CreateFirewallPolicy(ctx, site, policy)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Create Firewall Policy",
+			fmt.Sprintf("Failed to create firewall policy using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	resp.Diagnostics.Append(plan.readFrom(ctx, createdPolicy)...)
+	plan.Site = types.StringValue(site)
+
+	// save state with the populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *firewallPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// retrieve the current state
+	var state firewallPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// refresh value from the API
+	site := siteFromModel(state.Site, r.client)
+	policy, err := r.client.GetFirewallPolicy(ctx, site, state.ID.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		// the policy was removed outside of Terraform so drop it from the state to have it recreated
+		tflog.Warn(ctx, "firewall policy no longer exists - removing from state", map[string]any{
+			"id":   state.ID.ValueString(),
+			"site": site,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve Firewall Policy",
+			fmt.Sprintf("Failed to retrieve the firewall policy with the ID '%s': %s",
+				state.ID.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// update the state
+	resp.Diagnostics.Append(state.readFrom(ctx, policy)...)
+	state.Site = types.StringValue(site)
+
+	// set the refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *firewallPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan firewallPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// start from the current policy since the UDM replaces the whole policy, which preserves fields not managed by
+	// Terraform
+	site := siteFromModel(plan.Site, r.client)
+	policy, err := r.client.GetFirewallPolicy(ctx, site, plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve Firewall Policy",
+			fmt.Sprintf("Failed to retrieve the firewall policy with the ID '%s': %s",
+				plan.ID.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// generate API request body from plan
+	resp.Diagnostics.Append(plan.applyTo(ctx, &policy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the policy
+	updatedPolicy, err := r.client.UpdateFirewallPolicy(ctx, site, plan.ID.ValueString(), policy)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Update Firewall Policy",
+			fmt.Sprintf("Failed to update firewall policy using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	resp.Diagnostics.Append(plan.readFrom(ctx, updatedPolicy)...)
+	plan.Site = types.StringValue(site)
+
+	// save state with the populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *firewallPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// retrieve the current state
+	var state firewallPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the policy
+	if err := r.client.DeleteFirewallPolicy(ctx, siteFromModel(state.Site, r.client),
+		state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Delete Firewall Policy",
+			fmt.Sprintf("Failed to delete firewall policy using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+}
+
+// ModifyPlan checks that the UDM supports the zone-based firewall, sets the planned site of the policy and replaces
+// the policy when it is moved to another site.
+func (r *firewallPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// policies can always be destroyed
+	if !req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(requireFeature(r.client, api.FeatureZoneBasedFirewall)...)
+	}
+	modifySitePlan(ctx, r.client, req, resp)
+}
+
+// ImportState imports a policy by its ID or by its site and ID (ie: "site/id").
+func (r *firewallPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateWithSite(ctx, r.client, req, resp)
+}
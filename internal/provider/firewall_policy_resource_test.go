@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+const testAccFirewallPolicyZonesConfig = testAccWLANNetworkConfig + `
+resource "udm_firewall_zone" "guest" {
+  name        = "Guest"
+  network_ids = [udm_network.guest.id]
+}
+
+resource "udm_firewall_zone" "servers" {
+  name = "Servers"
+}
+`
+
+func TestAccFirewallPolicyResource(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCollectionEmpty(server, udmmock.DefaultSite, udmmock.CollectionFirewallPolicies),
+		Steps: []resource.TestStep{
+			// create and read
+			{
+				Config: providerConfig + testAccFirewallPolicyZonesConfig + `
+resource "udm_firewall_policy" "test" {
+  name     = "Guests to web servers"
+  action   = "allow"
+  protocol = "tcp"
+
+  source = {
+    zone_id     = udm_firewall_zone.guest.id
+    network_ids = [udm_network.guest.id]
+  }
+
+  destination = {
+    zone_id = udm_firewall_zone.servers.id
+    ips     = ["10.0.10.0/24", "10.0.20.5"]
+    port    = "80,443"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("udm_firewall_policy.test", "id"),
+					resource.TestCheckResourceAttr("udm_firewall_policy.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("udm_firewall_policy.test", "index", "10000"),
+					resource.TestCheckResourceAttr("udm_firewall_policy.test", "ip_version", "both"),
+					resource.TestCheckResourceAttr("udm_firewall_policy.test", "connection_states.#", "0"),
+					resource.TestCheckNoResourceAttr("udm_firewall_policy.test", "schedule"),
+					resource.TestCheckResourceAttrPair("udm_firewall_policy.test", "source.zone_id",
+						"udm_firewall_zone.guest", "id"),
+					resource.TestCheckResourceAttr("udm_firewall_policy.test", "destination.ips.#", "2"),
+					resource.TestCheckResourceAttr("udm_firewall_policy.test", "destination.port", "80,443"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallPolicies,
+						"udm_firewall_policy.test", "action", "ALLOW"),
+				),
+			},
+			// import
+			{
+				ResourceName:      "udm_firewall_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// update the matching criteria, schedule and ordering
+			{
+				Config: providerConfig + testAccFirewallPolicyZonesConfig + `
+resource "udm_firewall_policy" "test" {
+  name              = "Block new guest connections at night"
+  description       = "Managed by Terraform"
+  action            = "block"
+  index             = 10005
+  ip_version        = "ipv4"
+  logging           = true
+  connection_states = ["new", "invalid"]
+
+  source = {
+    zone_id = udm_firewall_zone.guest.id
+    ips     = ["192.168.30.0/24"]
+  }
+
+  destination = {
+    zone_id = udm_firewall_zone.servers.id
+  }
+
+  schedule = {
+    mode         = "every_week"
+    days_of_week = ["mon", "tue", "wed", "thu", "fri"]
+    start_time   = "22:00"
+    end_time     = "23:59"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_firewall_policy.test", "action", "block"),
+					resource.TestCheckResourceAttr("udm_firewall_policy.test", "index", "10005"),
+					resource.TestCheckResourceAttr("udm_firewall_policy.test", "protocol", "tcp"),
+					resource.TestCheckResourceAttr("udm_firewall_policy.test", "connection_states.#", "2"),
+					resource.TestCheckNoResourceAttr("udm_firewall_policy.test", "destination.ips"),
+					resource.TestCheckResourceAttr("udm_firewall_policy.test", "schedule.days_of_week.#", "5"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallPolicies,
+						"udm_firewall_policy.test", "connection_state_type", "CUSTOM"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallPolicies,
+						"udm_firewall_policy.test", "ip_version", "IPV4"),
+				),
+			},
+			// recreate after the policy was deleted outside of Terraform
+			{
+				PreConfig: func() {
+					testAccDeleteAllObjects(server, udmmock.DefaultSite, udmmock.CollectionFirewallPolicies)
+				},
+				Config: providerConfig + testAccFirewallPolicyZonesConfig + `
+resource "udm_firewall_policy" "test" {
+  name   = "Block guests"
+  action = "reject"
+
+  source = {
+    zone_id = udm_firewall_zone.guest.id
+  }
+
+  destination = {
+    zone_id = udm_firewall_zone.servers.id
+  }
+
+  schedule = {
+    mode = "every_day"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_firewall_policy.test", "schedule.mode", "every_day"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallPolicies,
+						"udm_firewall_policy.test", "action", "REJECT"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFirewallPolicyResourceValidation(t *testing.T) {
+	_, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "udm_firewall_policy" "test" {
+  name     = "Ports"
+  action   = "block"
+  protocol = "icmp"
+
+  source = {
+    zone_id = "000000000000000000000001"
+  }
+
+  destination = {
+    zone_id = "000000000000000000000002"
+    port    = "22"
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Ports Require TCP or UDP`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_firewall_policy" "test" {
+  name       = "Family"
+  action     = "block"
+  ip_version = "ipv6"
+
+  source = {
+    zone_id = "000000000000000000000001"
+    ips     = ["192.168.1.10"]
+  }
+
+  destination = {
+    zone_id = "000000000000000000000002"
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Address Family Mismatch`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_firewall_policy" "test" {
+  name   = "Weekly"
+  action = "block"
+
+  source = {
+    zone_id = "000000000000000000000001"
+  }
+
+  destination = {
+    zone_id = "000000000000000000000002"
+  }
+
+  schedule = {
+    mode = "every_week"
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing Days of Week`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_firewall_policy" "test" {
+  name   = "Both"
+  action = "block"
+
+  source = {
+    zone_id     = "000000000000000000000001"
+    ips         = ["192.168.1.10"]
+    network_ids = ["000000000000000000000003"]
+  }
+
+  destination = {
+    zone_id = "000000000000000000000002"
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccFirewallPolicyResourceUnsupportedVersion(t *testing.T) {
+	server, providerConfig := newTestAccServerWithConfig(t, udmmock.Config{NetworkAppVersion: "8.6.9"})
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "udm_firewall_policy" "test" {
+  name   = "Block guests"
+  action = "block"
+
+  source = {
+    zone_id = "000000000000000000000001"
+  }
+
+  destination = {
+    zone_id = "000000000000000000000002"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`require Network application >= 9\.0\.0`),
+			},
+		},
+	})
+	if n := server.RequestCount("POST", "/proxy/network/v2/api/site/default/firewall-policies"); n != 0 {
+		t.Errorf("%d firewall policies were created, want 0", n)
+	}
+}
@@ -0,0 +1,645 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &firewallRuleResource{}
+	_ resource.ResourceWithConfigure      = &firewallRuleResource{}
+	_ resource.ResourceWithImportState    = &firewallRuleResource{}
+	_ resource.ResourceWithModifyPlan     = &firewallRuleResource{}
+	_ resource.ResourceWithValidateConfig = &firewallRuleResource{}
+)
+
+// firewallRulesets are the rulesets of the legacy firewall which accept user-defined rules.
+var firewallRulesets = []string{
+	"GUEST_IN", "GUEST_LOCAL", "GUEST_OUT", "GUESTv6_IN", "GUESTv6_LOCAL", "GUESTv6_OUT",
+	"LAN_IN", "LAN_LOCAL", "LAN_OUT", "LANv6_IN", "LANv6_LOCAL", "LANv6_OUT",
+	"WAN_IN", "WAN_LOCAL", "WAN_OUT", "WANv6_IN", "WANv6_LOCAL", "WANv6_OUT",
+}
+
+// firewallRulesetLocks serializes the index check and the write of rules in the same ruleset of a site, since Terraform
+// creates and updates resources in parallel and two rules could otherwise both find the same index free.  Deletes do
+// not take the lock as they only ever free an index.
+var firewallRulesetLocks = &rulesetLocks{locks: map[string]*sync.Mutex{}}
+
+// rulesetLocks holds a mutex for each site and ruleset.
+type rulesetLocks struct {
+	lock  sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// acquire locks the mutex for the given site and ruleset and returns the function which unlocks it.
+func (l *rulesetLocks) acquire(site, ruleset string) func() {
+	key := site + "/" + ruleset
+	l.lock.Lock()
+	m, ok := l.locks[key]
+	if !ok {
+		m = &sync.Mutex{}
+		l.locks[key] = m
+	}
+	l.lock.Unlock()
+
+	m.Lock()
+	return m.Unlock
+}
+
+// NewFirewallRuleResource is a helper function to simplify the provider implementation.
+func NewFirewallRuleResource() resource.Resource {
+	return &firewallRuleResource{}
+}
+
+// firewallRuleResource is the resource implementation.
+type firewallRuleResource struct {
+	client *api.Client
+}
+
+type firewallRuleResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	Action                types.String `tfsdk:"action"`
+	DstAddress            types.String `tfsdk:"dst_address"`
+	DstFirewallGroupIDs   types.Set    `tfsdk:"dst_firewall_group_ids"`
+	DstNetworkID          types.String `tfsdk:"dst_network_id"`
+	DstPort               types.String `tfsdk:"dst_port"`
+	Enabled               types.Bool   `tfsdk:"enabled"`
+	IPSec                 types.String `tfsdk:"ipsec"`
+	Logging               types.Bool   `tfsdk:"logging"`
+	Name                  types.String `tfsdk:"name"`
+	Protocol              types.String `tfsdk:"protocol"`
+	ProtocolMatchExcepted types.Bool   `tfsdk:"protocol_match_excepted"`
+	RuleIndex             types.Int32  `tfsdk:"rule_index"`
+	Ruleset               types.String `tfsdk:"ruleset"`
+	Site                  types.String `tfsdk:"site"`
+	SrcAddress            types.String `tfsdk:"src_address"`
+	SrcFirewallGroupIDs   types.Set    `tfsdk:"src_firewall_group_ids"`
+	SrcMACAddress         types.String `tfsdk:"src_mac_address"`
+	SrcNetworkID          types.String `tfsdk:"src_network_id"`
+	SrcPort               types.String `tfsdk:"src_port"`
+	StateEstablished      types.Bool   `tfsdk:"state_established"`
+	StateInvalid          types.Bool   `tfsdk:"state_invalid"`
+	StateNew              types.Bool   `tfsdk:"state_new"`
+	StateRelated          types.Bool   `tfsdk:"state_related"`
+}
+
+// applyTo copies the values set in the model to a firewall rule, leaving the fields of any unset computed values
+// untouched.
+func (m firewallRuleResourceModel) applyTo(ctx context.Context, rule *api.FirewallRule) diag.Diagnostics {
+	var diags diag.Diagnostics
+	rule.Action = m.Action.ValueString()
+	rule.Name = m.Name.ValueString()
+	rule.RuleIndex = int(m.RuleIndex.ValueInt32())
+	rule.Ruleset = m.Ruleset.ValueString()
+	rule.IPSec = m.IPSec.ValueString()
+
+	// the UDM matches either an address or a network, which it needs to be told about along with the address family
+	family := "v4"
+	if isIPv6Ruleset(rule.Ruleset) {
+		family = "v6"
+	}
+	rule.SrcAddress = m.SrcAddress.ValueString()
+	rule.SrcMACAddress = m.SrcMACAddress.ValueString()
+	rule.SrcNetworkConfID = m.SrcNetworkID.ValueString()
+	rule.SrcNetworkConfType = "ADDR" + family
+	if rule.SrcNetworkConfID != "" {
+		rule.SrcNetworkConfType = "NET" + family
+	}
+	rule.SrcPort = m.SrcPort.ValueString()
+	rule.DstAddress = m.DstAddress.ValueString()
+	rule.DstNetworkConfID = m.DstNetworkID.ValueString()
+	rule.DstNetworkConfType = "ADDR" + family
+	if rule.DstNetworkConfID != "" {
+		rule.DstNetworkConfType = "NET" + family
+	}
+	rule.DstPort = m.DstPort.ValueString()
+
+	if !m.SrcFirewallGroupIDs.IsNull() && !m.SrcFirewallGroupIDs.IsUnknown() {
+		rule.SrcFirewallGroupIDs = []string{}
+		diags.Append(m.SrcFirewallGroupIDs.ElementsAs(ctx, &rule.SrcFirewallGroupIDs, false)...)
+	}
+	rule.SrcFirewallGroupIDs = nonNilStrings(rule.SrcFirewallGroupIDs)
+	if !m.DstFirewallGroupIDs.IsNull() && !m.DstFirewallGroupIDs.IsUnknown() {
+		rule.DstFirewallGroupIDs = []string{}
+		diags.Append(m.DstFirewallGroupIDs.ElementsAs(ctx, &rule.DstFirewallGroupIDs, false)...)
+	}
+	rule.DstFirewallGroupIDs = nonNilStrings(rule.DstFirewallGroupIDs)
+	if !m.Enabled.IsNull() && !m.Enabled.IsUnknown() {
+		rule.Enabled = m.Enabled.ValueBool()
+	}
+	if !m.Logging.IsNull() && !m.Logging.IsUnknown() {
+		rule.Logging = m.Logging.ValueBool()
+	}
+	if !m.Protocol.IsNull() && !m.Protocol.IsUnknown() {
+		// the protocol of rules in IPv6 rulesets is held in a separate field
+		if isIPv6Ruleset(rule.Ruleset) {
+			rule.ProtocolV6 = m.Protocol.ValueString()
+		} else {
+			rule.Protocol = m.Protocol.ValueString()
+		}
+	}
+	if !m.ProtocolMatchExcepted.IsNull() && !m.ProtocolMatchExcepted.IsUnknown() {
+		rule.ProtocolMatchExcepted = m.ProtocolMatchExcepted.ValueBool()
+	}
+	if !m.StateEstablished.IsNull() && !m.StateEstablished.IsUnknown() {
+		rule.StateEstablished = m.StateEstablished.ValueBool()
+	}
+	if !m.StateInvalid.IsNull() && !m.StateInvalid.IsUnknown() {
+		rule.StateInvalid = m.StateInvalid.ValueBool()
+	}
+	if !m.StateNew.IsNull() && !m.StateNew.IsUnknown() {
+		rule.StateNew = m.StateNew.ValueBool()
+	}
+	if !m.StateRelated.IsNull() && !m.StateRelated.IsUnknown() {
+		rule.StateRelated = m.StateRelated.ValueBool()
+	}
+	return diags
+}
+
+// readFrom sets the model to the values of a firewall rule.
+func (m *firewallRuleResourceModel) readFrom(ctx context.Context, rule api.FirewallRule) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	m.ID = types.StringValue(rule.ID)
+	m.Action = types.StringValue(rule.Action)
+	m.DstAddress = optionalString(rule.DstAddress)
+	m.DstFirewallGroupIDs, d = types.SetValueFrom(ctx, types.StringType, nonNilStrings(rule.DstFirewallGroupIDs))
+	diags.Append(d...)
+	m.DstNetworkID = optionalString(rule.DstNetworkConfID)
+	m.DstPort = optionalString(rule.DstPort)
+	m.Enabled = types.BoolValue(rule.Enabled)
+	m.IPSec = optionalString(rule.IPSec)
+	m.Logging = types.BoolValue(rule.Logging)
+	m.Name = types.StringValue(rule.Name)
+	m.Protocol = types.StringValue(rule.Protocol)
+	if isIPv6Ruleset(rule.Ruleset) {
+		m.Protocol = types.StringValue(rule.ProtocolV6)
+	}
+	m.ProtocolMatchExcepted = types.BoolValue(rule.ProtocolMatchExcepted)
+	m.RuleIndex = types.Int32Value(int32(rule.RuleIndex))
+	m.Ruleset = types.StringValue(rule.Ruleset)
+	m.SrcAddress = optionalString(rule.SrcAddress)
+	m.SrcFirewallGroupIDs, d = types.SetValueFrom(ctx, types.StringType, nonNilStrings(rule.SrcFirewallGroupIDs))
+	diags.Append(d...)
+	m.SrcMACAddress = optionalString(rule.SrcMACAddress)
+	m.SrcNetworkID = optionalString(rule.SrcNetworkConfID)
+	m.SrcPort = optionalString(rule.SrcPort)
+	m.StateEstablished = types.BoolValue(rule.StateEstablished)
+	m.StateInvalid = types.BoolValue(rule.StateInvalid)
+	m.StateNew = types.BoolValue(rule.StateNew)
+	m.StateRelated = types.BoolValue(rule.StateRelated)
+	return diags
+}
+
+// isIPv6Ruleset returns whether or not a ruleset filters IPv6 traffic.
+func isIPv6Ruleset(ruleset string) bool {
+	return strings.Contains(ruleset, "v6_")
+}
+
+// optionalString returns a null string in place of an empty string for optional attributes the UDM stores as empty
+// strings when they are not set.
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+func (r *firewallRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *firewallRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_rule"
+}
+
+// Schema defines the schema for the resource.
+func (r *firewallRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"action": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("accept", "drop", "reject"),
+				},
+			},
+			"dst_address": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.Any(ipAddressValidator{}, cidrValidator{}),
+					stringvalidator.ConflictsWith(path.MatchRoot("dst_network_id")),
+				},
+			},
+			"dst_firewall_group_ids": schema.SetAttribute{
+				Computed:    true,
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"dst_network_id": schema.StringAttribute{
+				Optional: true,
+			},
+			"dst_port": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					portsValidator{},
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"ipsec": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("match-ipsec", "match-none"),
+				},
+			},
+			"logging": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"protocol": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("all", "tcp", "udp", "tcp_udp", "icmp", "icmpv6", "ah", "esp", "gre", "igmp",
+						"sctp", "vrrp"),
+				},
+			},
+			"protocol_match_excepted": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"rule_index": schema.Int32Attribute{
+				Required: true,
+				Validators: []validator.Int32{
+					// rules in 2000-2999 are applied before the predefined rules and rules in 4000-4999 after them
+					int32validator.Any(int32validator.Between(2000, 2999), int32validator.Between(4000, 4999)),
+				},
+			},
+			"ruleset": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(firewallRulesets...),
+				},
+			},
+			"site": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"src_address": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.Any(ipAddressValidator{}, cidrValidator{}),
+					stringvalidator.ConflictsWith(path.MatchRoot("src_network_id")),
+				},
+			},
+			"src_firewall_group_ids": schema.SetAttribute{
+				Computed:    true,
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"src_mac_address": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					macAddressValidator{},
+				},
+			},
+			"src_network_id": schema.StringAttribute{
+				Optional: true,
+			},
+			"src_port": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					portsValidator{},
+				},
+			},
+			"state_established": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"state_invalid": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"state_new": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"state_related": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that ports are only matched for TCP and UDP and that addresses belong to the address family of
+// the ruleset.
+func (r *firewallRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config firewallRuleResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Protocol.IsUnknown() {
+		switch config.Protocol.ValueString() {
+		case "tcp", "udp", "tcp_udp":
+		default:
+			for _, port := range []struct {
+				name  string
+				value types.String
+			}{
+				{"src_port", config.SrcPort},
+				{"dst_port", config.DstPort},
+			} {
+				if !port.value.IsNull() {
+					resp.Diagnostics.AddAttributeError(
+						path.Root(port.name),
+						"Ports Require TCP or UDP",
+						"Ports can only be matched by rules with a protocol of 'tcp', 'udp' or 'tcp_udp'.",
+					)
+				}
+			}
+		}
+	}
+
+	// malformed addresses are reported by the validators
+	if config.Ruleset.IsUnknown() {
+		return
+	}
+	ipv6 := isIPv6Ruleset(config.Ruleset.ValueString())
+	for _, address := range []struct {
+		name  string
+		value types.String
+	}{
+		{"src_address", config.SrcAddress},
+		{"dst_address", config.DstAddress},
+	} {
+		if address.value.IsNull() || address.value.IsUnknown() {
+			continue
+		}
+		prefix, err := parseAddressOrPrefix(address.value.ValueString())
+		if err == nil && prefix.Addr().Is6() != ipv6 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(address.name),
+				"Address Family Mismatch",
+				fmt.Sprintf("The address %s does not belong to the address family of the %s ruleset.",
+					address.value.ValueString(), config.Ruleset.ValueString()),
+			)
+		}
+	}
+}
+
+// checkRuleIndex adds an error to diags if the index of a rule is already used by another rule in its ruleset.
+//
+// The UDM rejects such rules as well, but only with a generic error which does not say which rule holds the index.
+func (r *firewallRuleResource) checkRuleIndex(ctx context.Context, site string, rule api.FirewallRule,
+	diags *diag.Diagnostics) {
+
+	existing, err := r.client.GetFirewallRuleByIndex(ctx, site, rule.Ruleset, rule.RuleIndex)
+	switch {
+	case errors.Is(err, api.ErrNotFound):
+		return
+	case err != nil:
+		diags.AddError(
+			"UDM API: Failed to Retrieve Firewall Rules",
+			fmt.Sprintf("Failed to retrieve firewall rules from the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+	case existing.ID != rule.ID:
+		diags.AddAttributeError(
+			path.Root("rule_index"),
+			"Firewall Rule Index In Use",
+			fmt.Sprintf("The index %d of the %s ruleset is already used by the firewall rule '%s' (ID: %s).  Choose "+
+				"another index or move the other rule to a free index in a separate apply first.", rule.RuleIndex,
+				rule.Ruleset, existing.Name, existing.ID),
+		)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *firewallRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan firewallRuleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// generate API request body from plan - new rules are enabled and match all protocols unless stated otherwise
+	rule := api.FirewallRule{
+		Enabled:    true,
+		Protocol:   "all",
+		ProtocolV6: "all",
+	}
+	resp.Diagnostics.Append(plan.applyTo(ctx, &rule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// make sure the rule would not collide with another rule
+	site := siteFromModel(plan.Site, r.client)
+	defer firewallRulesetLocks.acquire(site, rule.Ruleset)()
+	r.checkRuleIndex(ctx, site, rule, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the rule
+	createdRule, err := r.client.CreateFirewallRule(ctx, site, rule)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Create Firewall Rule",
+			fmt.Sprintf("Failed to create firewall rule using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	resp.Diagnostics.Append(plan.readFrom(ctx, createdRule)...)
+	plan.Site = types.StringValue(site)
+
+	// save state with the populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *firewallRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// retrieve the current state
+	var state firewallRuleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// refresh value from the API
+	site := siteFromModel(state.Site, r.client)
+	rule, err := r.client.GetFirewallRule(ctx, site, state.ID.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		// the rule was removed outside of Terraform so drop it from the state to have it recreated
+		tflog.Warn(ctx, "firewall rule no longer exists - removing from state", map[string]any{
+			"id":   state.ID.ValueString(),
+			"site": site,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve Firewall Rule",
+			fmt.Sprintf("Failed to retrieve the firewall rule with the ID '%s': %s",
+				state.ID.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// update the state
+	resp.Diagnostics.Append(state.readFrom(ctx, rule)...)
+	state.Site = types.StringValue(site)
+
+	// set the refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *firewallRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan and state
+	var plan, state firewallRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// start from the current rule so fields not managed by Terraform are preserved
+	site := siteFromModel(plan.Site, r.client)
+	rule, err := r.client.GetFirewallRule(ctx, site, plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve Firewall Rule",
+			fmt.Sprintf("Failed to retrieve the firewall rule with the ID '%s': %s",
+				plan.ID.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// generate API request body from plan
+	resp.Diagnostics.Append(plan.applyTo(ctx, &rule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// make sure a rule moved to another index would not collide with another rule
+	if !plan.RuleIndex.Equal(state.RuleIndex) || !plan.Ruleset.Equal(state.Ruleset) {
+		defer firewallRulesetLocks.acquire(site, rule.Ruleset)()
+		r.checkRuleIndex(ctx, site, rule, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// update the rule
+	updatedRule, err := r.client.UpdateFirewallRule(ctx, site, plan.ID.ValueString(), rule)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Update Firewall Rule",
+			fmt.Sprintf("Failed to update firewall rule using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	resp.Diagnostics.Append(plan.readFrom(ctx, updatedRule)...)
+	plan.Site = types.StringValue(site)
+
+	// save state with the populated data
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *firewallRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// retrieve the current state
+	var state firewallRuleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the rule
+	if err := r.client.DeleteFirewallRule(ctx, siteFromModel(state.Site, r.client), state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Delete Firewall Rule",
+			fmt.Sprintf("Failed to delete firewall rule using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+}
+
+// ModifyPlan sets the planned site of the rule and replaces the rule when it is moved to another site.
+func (r *firewallRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifySitePlan(ctx, r.client, req, resp)
+}
+
+// ImportState imports a rule by its ID or by its site and ID (ie: "site/id").
+func (r *firewallRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateWithSite(ctx, r.client, req, resp)
+}
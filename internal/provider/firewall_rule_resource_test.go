@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestAccFirewallRuleResource(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckCollectionEmpty(server, udmmock.DefaultSite,
+			udmmock.CollectionFirewallRules),
+		Steps: []resource.TestStep{
+			// create and read
+			{
+				Config: providerConfig + testAccWLANNetworkConfig + `
+resource "udm_firewall_rule" "test" {
+  name           = "Block guests from the NAS"
+  ruleset        = "LAN_IN"
+  rule_index     = 2000
+  action         = "drop"
+  protocol       = "tcp"
+  src_network_id = udm_network.guest.id
+  dst_address    = "192.168.1.10"
+  dst_port       = "139,445"
+  state_new      = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("udm_firewall_rule.test", "id"),
+					resource.TestCheckResourceAttr("udm_firewall_rule.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("udm_firewall_rule.test", "logging", "false"),
+					resource.TestCheckNoResourceAttr("udm_firewall_rule.test", "src_address"),
+					resource.TestCheckResourceAttr("udm_firewall_rule.test", "dst_firewall_group_ids.#", "0"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallRules,
+						"udm_firewall_rule.test", "src_networkconf_type", "NETv4"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallRules,
+						"udm_firewall_rule.test", "dst_networkconf_type", "ADDRv4"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallRules,
+						"udm_firewall_rule.test", "dst_port", "139,445"),
+				),
+			},
+			// import
+			{
+				ResourceName:      "udm_firewall_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// update and read
+			{
+				Config: providerConfig + testAccWLANNetworkConfig + `
+resource "udm_firewall_rule" "test" {
+  name              = "Reject guests from the NAS"
+  ruleset           = "LAN_IN"
+  rule_index        = 2010
+  action            = "reject"
+  protocol          = "all"
+  src_network_id    = udm_network.guest.id
+  dst_address       = "192.168.1.0/24"
+  logging           = true
+  state_new         = true
+  state_established = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_firewall_rule.test", "rule_index", "2010"),
+					resource.TestCheckNoResourceAttr("udm_firewall_rule.test", "dst_port"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallRules,
+						"udm_firewall_rule.test", "rule_index", float64(2010)),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallRules,
+						"udm_firewall_rule.test", "dst_port", ""),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallRules,
+						"udm_firewall_rule.test", "logging", true),
+				),
+			},
+			// recreate after the rule was deleted outside of Terraform
+			{
+				PreConfig: func() {
+					testAccDeleteAllObjects(server, udmmock.DefaultSite, udmmock.CollectionFirewallRules)
+				},
+				Config: providerConfig + `
+resource "udm_firewall_rule" "test" {
+  name        = "Block IPv6 telnet"
+  ruleset     = "WANv6_IN"
+  rule_index  = 4000
+  action      = "drop"
+  protocol    = "tcp"
+  dst_address = "2001:db8::/64"
+  dst_port    = "23"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_firewall_rule.test", "protocol", "tcp"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallRules,
+						"udm_firewall_rule.test", "dst_networkconf_type", "ADDRv6"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallRules,
+						"udm_firewall_rule.test", "protocol_v6", "tcp"),
+				),
+			},
+			// the protocol of IPv6 rules is read from a separate field
+			{
+				ResourceName:      "udm_firewall_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccFirewallRuleResourceIndexInUse(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	server.AddObject(udmmock.DefaultSite, udmmock.CollectionFirewallRules, map[string]any{
+		"action":     "accept",
+		"enabled":    true,
+		"name":       "Allow management",
+		"protocol":   "all",
+		"rule_index": float64(2000),
+		"ruleset":    "LAN_IN",
+	})
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// the index is free in another ruleset
+			{
+				Config: providerConfig + `
+resource "udm_firewall_rule" "test" {
+  name          = "Drop invalid"
+  ruleset       = "LAN_LOCAL"
+  rule_index    = 2000
+  action        = "drop"
+  state_invalid = true
+}
+`,
+			},
+			// but not in the ruleset of the existing rule
+			{
+				Config: providerConfig + `
+resource "udm_firewall_rule" "test" {
+  name          = "Drop invalid"
+  ruleset       = "LAN_IN"
+  rule_index    = 2000
+  action        = "drop"
+  state_invalid = true
+}
+`,
+				ExpectError: regexp.MustCompile(`already used by the firewall rule\s+'Allow\s+management'`),
+			},
+		},
+	})
+	if n := len(server.Objects(udmmock.DefaultSite, udmmock.CollectionFirewallRules)); n != 1 {
+		t.Errorf("%d firewall rules remain, want only the existing rule", n)
+	}
+}
+
+func TestAccFirewallRuleResourceConcurrentIndex(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// rules created in parallel cannot both take the same index
+			{
+				Config: providerConfig + `
+resource "udm_firewall_rule" "first" {
+  name       = "Drop invalid"
+  ruleset    = "LAN_IN"
+  rule_index = 2000
+  action     = "drop"
+}
+
+resource "udm_firewall_rule" "second" {
+  name       = "Drop spoofed"
+  ruleset    = "LAN_IN"
+  rule_index = 2000
+  action     = "drop"
+}
+`,
+				ExpectError: regexp.MustCompile(`Firewall Rule Index In Use`),
+			},
+		},
+	})
+	if n := len(server.Objects(udmmock.DefaultSite, udmmock.CollectionFirewallRules)); n != 0 {
+		t.Errorf("%d firewall rules remain, want none", n)
+	}
+}
+
+func TestAccFirewallRuleResourceValidation(t *testing.T) {
+	_, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "udm_firewall_rule" "test" {
+  name       = "Block DNS"
+  ruleset    = "LAN_IN"
+  rule_index = 2000
+  action     = "drop"
+  dst_port   = "53"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Ports Require TCP or UDP`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_firewall_rule" "test" {
+  name        = "Block telnet"
+  ruleset     = "WANv6_IN"
+  rule_index  = 2000
+  action      = "drop"
+  protocol    = "tcp"
+  dst_address = "192.168.1.0/24"
+  dst_port    = "23"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Address Family Mismatch`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_firewall_rule" "test" {
+  name       = "Block high ports"
+  ruleset    = "WAN_IN"
+  rule_index = 2000
+  action     = "drop"
+  protocol   = "tcp"
+  dst_port   = "9000-8000"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Ports`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_firewall_rule" "test" {
+  name       = "Block everything"
+  ruleset    = "WAN_IN"
+  rule_index = 3000
+  action     = "drop"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`rule_index value must be between 4000 and 4999`),
+			},
+		},
+	})
+}
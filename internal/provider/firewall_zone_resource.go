@@ -0,0 +1,325 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &firewallZoneResource{}
+	_ resource.ResourceWithConfigure   = &firewallZoneResource{}
+	_ resource.ResourceWithImportState = &firewallZoneResource{}
+	_ resource.ResourceWithModifyPlan  = &firewallZoneResource{}
+)
+
+// NewFirewallZoneResource is a helper function to simplify the provider implementation.
+func NewFirewallZoneResource() resource.Resource {
+	return &firewallZoneResource{}
+}
+
+// firewallZoneResource is the resource implementation.
+type firewallZoneResource struct {
+	client *api.Client
+}
+
+type firewallZoneResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	NetworkIDs types.Set    `tfsdk:"network_ids"`
+	Site       types.String `tfsdk:"site"`
+}
+
+func (r *firewallZoneResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *firewallZoneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_zone"
+}
+
+// Schema defines the schema for the resource.
+func (r *firewallZoneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"network_ids": schema.SetAttribute{
+				Computed:    true,
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"site": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *firewallZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan firewallZoneResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// generate API request body from plan
+	zone := api.FirewallZone{
+		Name:       plan.Name.ValueString(),
+		NetworkIDs: []string{},
+	}
+	if !plan.NetworkIDs.IsUnknown() {
+		resp.Diagnostics.Append(plan.NetworkIDs.ElementsAs(ctx, &zone.NetworkIDs, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the zone
+	site := siteFromModel(plan.Site, r.client)
+	createdZone, err := r.client.CreateFirewallZone(ctx, site, zone)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Create Firewall Zone",
+			fmt.Sprintf("Failed to create firewall zone using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	plan.ID = types.StringValue(createdZone.ID)
+	plan.Name = types.StringValue(createdZone.Name)
+	plan.NetworkIDs, diags = types.SetValueFrom(ctx, types.StringType, nonNilStrings(createdZone.NetworkIDs))
+	resp.Diagnostics.Append(diags...)
+	plan.Site = types.StringValue(site)
+
+	// save state with the populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *firewallZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// retrieve the current state
+	var state firewallZoneResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// refresh value from the API
+	site := siteFromModel(state.Site, r.client)
+	zone, err := r.client.GetFirewallZone(ctx, site, state.ID.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		// the zone was removed outside of Terraform so drop it from the state to have it recreated
+		tflog.Warn(ctx, "firewall zone no longer exists - removing from state", map[string]any{
+			"id":   state.ID.ValueString(),
+			"site": site,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve Firewall Zone",
+			fmt.Sprintf("Failed to retrieve the firewall zone with the ID '%s': %s",
+				state.ID.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// update the state
+	state.Name = types.StringValue(zone.Name)
+	state.NetworkIDs, diags = types.SetValueFrom(ctx, types.StringType, nonNilStrings(zone.NetworkIDs))
+	resp.Diagnostics.Append(diags...)
+	state.Site = types.StringValue(site)
+
+	// set the refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *firewallZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan and state
+	var plan, state firewallZoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// generate API request body from plan - networks no longer assigned to the zone are returned to the internal zone
+	// by the UDM, so networks left out of the configuration must be kept rather than sending an empty list
+	site := siteFromModel(plan.Site, r.client)
+	zone := api.FirewallZone{
+		ID:         plan.ID.ValueString(),
+		Name:       plan.Name.ValueString(),
+		NetworkIDs: []string{},
+	}
+	switch {
+	case !plan.NetworkIDs.IsUnknown():
+		resp.Diagnostics.Append(plan.NetworkIDs.ElementsAs(ctx, &zone.NetworkIDs, false)...)
+	case !state.NetworkIDs.IsNull() && !state.NetworkIDs.IsUnknown():
+		resp.Diagnostics.Append(state.NetworkIDs.ElementsAs(ctx, &zone.NetworkIDs, false)...)
+	default:
+		currentZone, err := r.client.GetFirewallZone(ctx, site, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"UDM API: Failed to Retrieve Firewall Zone",
+				fmt.Sprintf("Failed to retrieve the firewall zone with the ID '%s': %s",
+					plan.ID.ValueString(), apiErrorDetail(err)),
+			)
+			return
+		}
+		zone.NetworkIDs = nonNilStrings(currentZone.NetworkIDs)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the zone
+	updatedZone, err := r.client.UpdateFirewallZone(ctx, site, plan.ID.ValueString(), zone)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Update Firewall Zone",
+			fmt.Sprintf("Failed to update firewall zone using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	plan.Name = types.StringValue(updatedZone.Name)
+	networkIDs, diags := types.SetValueFrom(ctx, types.StringType, nonNilStrings(updatedZone.NetworkIDs))
+	resp.Diagnostics.Append(diags...)
+	plan.NetworkIDs = networkIDs
+	plan.Site = types.StringValue(site)
+
+	// save state with the populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *firewallZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// retrieve the current state
+	var state firewallZoneResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the zone
+	if err := r.client.DeleteFirewallZone(ctx, siteFromModel(state.Site, r.client), state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Delete Firewall Zone",
+			fmt.Sprintf("Failed to delete firewall zone using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+}
+
+// ModifyPlan checks that the UDM supports the zone-based firewall, sets the planned site of the zone and replaces the
+// zone when it is moved to another site.
+func (r *firewallZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// zones can always be destroyed
+	if !req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(requireFeature(r.client, api.FeatureZoneBasedFirewall)...)
+	}
+	modifySitePlan(ctx, r.client, req, resp)
+}
+
+// ImportState imports a zone by its ID or name, optionally prefixed by its site (ie: "site/id" or "site/name").
+func (r *firewallZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	site, id, ok := parseImportID(r.client, req, resp)
+	if !ok {
+		return
+	}
+
+	// look up the ID of the zone if it was imported by its name
+	if !isObjectID(id) {
+		zones, err := r.client.GetFirewallZones(ctx, site)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"UDM API: Failed to Retrieve Firewall Zones",
+				fmt.Sprintf("Failed to retrieve firewall zones from the UDM API:\n\t%s", apiErrorDetail(err)),
+			)
+			return
+		}
+		name := id
+		id = ""
+		for _, zone := range zones {
+			if zone.Name == name {
+				id = zone.ID
+				break
+			}
+		}
+		if id == "" {
+			resp.Diagnostics.AddError(
+				"Firewall Zone Not Found",
+				fmt.Sprintf("No firewall zone named '%s' exists in the site '%s'.", name, site),
+			)
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), site)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+// testAccCheckNetworkInZone returns a check which verifies whether or not the network of the given resource is assigned
+// to the zone with the given name on the mock UDM.
+func testAccCheckNetworkInZone(server *udmmock.Server, networkResourceName, zoneName string,
+	want bool) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[networkResourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", networkResourceName)
+		}
+		for _, zone := range server.Objects(udmmock.DefaultSite, udmmock.CollectionFirewallZones) {
+			if zone["name"] != zoneName {
+				continue
+			}
+			networkIDs, _ := zone["network_ids"].([]any)
+			if got := slices.Contains(networkIDs, any(rs.Primary.ID)); got != want {
+				return fmt.Errorf("network %s in zone %s = %t, want %t", rs.Primary.ID, zoneName, got, want)
+			}
+			return nil
+		}
+		return fmt.Errorf("no zone named %s exists", zoneName)
+	}
+}
+
+func TestAccFirewallZoneResource(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckCollectionEmpty(server, udmmock.DefaultSite, udmmock.CollectionNetworks),
+			func(*terraform.State) error {
+				// only the built-in zones remain
+				for _, zone := range server.Objects(udmmock.DefaultSite, udmmock.CollectionFirewallZones) {
+					if zone["default_zone"] != true {
+						return fmt.Errorf("the firewall zone %v was not destroyed", zone["name"])
+					}
+				}
+				return nil
+			},
+		),
+		Steps: []resource.TestStep{
+			// create and read
+			{
+				Config: providerConfig + testAccWLANNetworkConfig + `
+resource "udm_firewall_zone" "test" {
+  name        = "Untrusted"
+  network_ids = [udm_network.guest.id]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("udm_firewall_zone.test", "id"),
+					resource.TestCheckResourceAttr("udm_firewall_zone.test", "name", "Untrusted"),
+					resource.TestCheckResourceAttr("udm_firewall_zone.test", "site", udmmock.DefaultSite),
+					resource.TestCheckTypeSetElemAttrPair("udm_firewall_zone.test", "network_ids.*",
+						"udm_network.guest", "id"),
+					testAccCheckNetworkInZone(server, "udm_network.guest", "Untrusted", true),
+					testAccCheckNetworkInZone(server, "udm_network.guest", "Internal", false),
+				),
+			},
+			// import by name
+			{
+				ResourceName:      "udm_firewall_zone.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return "Untrusted", nil
+				},
+			},
+			// rename the zone without managing its networks
+			{
+				Config: providerConfig + testAccWLANNetworkConfig + `
+resource "udm_firewall_zone" "test" {
+  name = "Guests"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_firewall_zone.test", "name", "Guests"),
+					resource.TestCheckTypeSetElemAttrPair("udm_firewall_zone.test", "network_ids.*",
+						"udm_network.guest", "id"),
+					testAccCheckNetworkInZone(server, "udm_network.guest", "Guests", true),
+				),
+			},
+			// rename the zone and return the network to the internal zone
+			{
+				Config: providerConfig + testAccWLANNetworkConfig + `
+resource "udm_firewall_zone" "test" {
+  name        = "Quarantine"
+  network_ids = []
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_firewall_zone.test", "name", "Quarantine"),
+					resource.TestCheckResourceAttr("udm_firewall_zone.test", "network_ids.#", "0"),
+					testAccCheckNetworkInZone(server, "udm_network.guest", "Internal", true),
+				),
+			},
+			// recreate after the zone was deleted outside of Terraform
+			{
+				PreConfig: func() {
+					for _, zone := range server.Objects(udmmock.DefaultSite, udmmock.CollectionFirewallZones) {
+						if zone["default_zone"] != true {
+							server.DeleteObject(udmmock.DefaultSite, udmmock.CollectionFirewallZones, zone["_id"].(string))
+						}
+					}
+				},
+				Config: providerConfig + testAccWLANNetworkConfig + `
+resource "udm_firewall_zone" "test" {
+  name = "Quarantine"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionFirewallZones,
+						"udm_firewall_zone.test", "name", "Quarantine"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFirewallZoneResourceUnsupportedVersion(t *testing.T) {
+	server, providerConfig := newTestAccServerWithConfig(t, udmmock.Config{NetworkAppVersion: "8.6.9"})
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "udm_firewall_zone" "test" {
+  name = "Untrusted"
+}
+`,
+				ExpectError: regexp.MustCompile(`require Network application >= 9\.0\.0 but the UDM\s+is running 8\.6\.9`),
+			},
+		},
+	})
+	if n := server.RequestCount("POST", "/proxy/network/v2/api/site/default/firewall/zone"); n != 0 {
+		t.Errorf("%d firewall zones were created, want 0", n)
+	}
+}
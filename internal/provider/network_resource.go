@@ -0,0 +1,521 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &networkResource{}
+	_ resource.ResourceWithConfigure      = &networkResource{}
+	_ resource.ResourceWithImportState    = &networkResource{}
+	_ resource.ResourceWithModifyPlan     = &networkResource{}
+	_ resource.ResourceWithValidateConfig = &networkResource{}
+)
+
+// NewNetworkResource is a helper function to simplify the provider implementation.
+func NewNetworkResource() resource.Resource {
+	return &networkResource{}
+}
+
+// networkResource is the resource implementation.
+type networkResource struct {
+	client *api.Client
+}
+
+type networkResourceModel struct {
+	ID                      types.String `tfsdk:"id"`
+	DHCPDNSServers          types.List   `tfsdk:"dhcp_dns_servers"`
+	DHCPEnabled             types.Bool   `tfsdk:"dhcp_enabled"`
+	DHCPLeaseTime           types.Int32  `tfsdk:"dhcp_lease_time"`
+	DHCPStart               types.String `tfsdk:"dhcp_start"`
+	DHCPStop                types.String `tfsdk:"dhcp_stop"`
+	DomainName              types.String `tfsdk:"domain_name"`
+	Enabled                 types.Bool   `tfsdk:"enabled"`
+	GatewayIP               types.String `tfsdk:"gateway_ip"`
+	IGMPSnooping            types.Bool   `tfsdk:"igmp_snooping"`
+	IPv6InterfaceType       types.String `tfsdk:"ipv6_interface_type"`
+	Name                    types.String `tfsdk:"name"`
+	NetworkIsolationEnabled types.Bool   `tfsdk:"network_isolation_enabled"`
+	Purpose                 types.String `tfsdk:"purpose"`
+	Site                    types.String `tfsdk:"site"`
+	Subnet                  types.String `tfsdk:"subnet"`
+	VLANID                  types.Int32  `tfsdk:"vlan_id"`
+}
+
+// applyTo copies the values set in the model to a network, leaving the fields of any unset values untouched.
+func (m networkResourceModel) applyTo(ctx context.Context, network *api.Network) diag.Diagnostics {
+	var diags diag.Diagnostics
+	network.Name = m.Name.ValueString()
+	network.Purpose = m.Purpose.ValueString()
+	if network.Purpose != "wan" {
+		network.NetworkGroup = "LAN"
+	}
+	if !m.VLANID.IsUnknown() {
+		network.VLANEnabled = !m.VLANID.IsNull()
+		if !m.VLANID.IsNull() {
+			network.VLAN = int(m.VLANID.ValueInt32())
+		}
+	}
+	if !m.Subnet.IsNull() && !m.Subnet.IsUnknown() && !m.GatewayIP.IsNull() && !m.GatewayIP.IsUnknown() {
+		// the UDM stores the gateway IP address along with the prefix length of the subnet (eg: 192.168.1.1/24)
+		prefix, _ := netip.ParsePrefix(m.Subnet.ValueString())
+		network.IPSubnet = fmt.Sprintf("%s/%d", m.GatewayIP.ValueString(), prefix.Bits())
+	}
+	if !m.DHCPEnabled.IsNull() && !m.DHCPEnabled.IsUnknown() {
+		network.DHCPEnabled = m.DHCPEnabled.ValueBool()
+	}
+	if !m.DHCPStart.IsNull() && !m.DHCPStart.IsUnknown() {
+		network.DHCPStart = m.DHCPStart.ValueString()
+	}
+	if !m.DHCPStop.IsNull() && !m.DHCPStop.IsUnknown() {
+		network.DHCPStop = m.DHCPStop.ValueString()
+	}
+	if !m.DHCPLeaseTime.IsNull() && !m.DHCPLeaseTime.IsUnknown() {
+		network.DHCPLeaseTime = int(m.DHCPLeaseTime.ValueInt32())
+	}
+	if !m.DHCPDNSServers.IsNull() && !m.DHCPDNSServers.IsUnknown() {
+		var servers []string
+		diags.Append(m.DHCPDNSServers.ElementsAs(ctx, &servers, false)...)
+		network.SetDHCPDNSServers(servers)
+	}
+	if !m.DomainName.IsNull() && !m.DomainName.IsUnknown() {
+		network.DomainName = m.DomainName.ValueString()
+	}
+	if !m.Enabled.IsNull() && !m.Enabled.IsUnknown() {
+		network.Enabled = m.Enabled.ValueBool()
+	}
+	if !m.IGMPSnooping.IsNull() && !m.IGMPSnooping.IsUnknown() {
+		network.IGMPSnooping = m.IGMPSnooping.ValueBool()
+	}
+	if !m.IPv6InterfaceType.IsNull() && !m.IPv6InterfaceType.IsUnknown() {
+		network.IPv6InterfaceType = m.IPv6InterfaceType.ValueString()
+	}
+	if !m.NetworkIsolationEnabled.IsNull() && !m.NetworkIsolationEnabled.IsUnknown() {
+		network.NetworkIsolationEnabled = m.NetworkIsolationEnabled.ValueBool()
+	}
+	return diags
+}
+
+// readFrom sets the model to the values of a network.
+func (m *networkResourceModel) readFrom(ctx context.Context, network api.Network) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.ID = types.StringValue(network.ID)
+	m.DHCPEnabled = types.BoolValue(network.DHCPEnabled)
+	m.DHCPLeaseTime = types.Int32Value(int32(network.DHCPLeaseTime))
+	m.DHCPStart = types.StringValue(network.DHCPStart)
+	m.DHCPStop = types.StringValue(network.DHCPStop)
+	m.DHCPDNSServers, diags = types.ListValueFrom(ctx, types.StringType, network.DHCPDNSServers())
+	m.DomainName = types.StringValue(network.DomainName)
+	m.Enabled = types.BoolValue(network.Enabled)
+	m.IGMPSnooping = types.BoolValue(network.IGMPSnooping)
+	m.IPv6InterfaceType = types.StringValue(network.IPv6InterfaceType)
+	m.Name = types.StringValue(network.Name)
+	m.NetworkIsolationEnabled = types.BoolValue(network.NetworkIsolationEnabled)
+	m.Purpose = types.StringValue(network.Purpose)
+	m.VLANID = types.Int32Null()
+	if network.VLANEnabled {
+		m.VLANID = types.Int32Value(int32(network.VLAN))
+	}
+	m.GatewayIP = types.StringNull()
+	m.Subnet = types.StringNull()
+	if prefix, err := netip.ParsePrefix(network.IPSubnet); err == nil {
+		m.GatewayIP = types.StringValue(prefix.Addr().String())
+		m.Subnet = types.StringValue(prefix.Masked().String())
+	}
+	return diags
+}
+
+func (r *networkResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *networkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network"
+}
+
+// Schema defines the schema for the resource.
+func (r *networkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dhcp_dns_servers": schema.ListAttribute{
+				Computed:    true,
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(4),
+					listvalidator.ValueStringsAre(ipAddressValidator{}),
+				},
+			},
+			"dhcp_enabled": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"dhcp_lease_time": schema.Int32Attribute{
+				Computed: true,
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(60),
+				},
+			},
+			"dhcp_start": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Validators: []validator.String{
+					ipAddressValidator{},
+				},
+			},
+			"dhcp_stop": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Validators: []validator.String{
+					ipAddressValidator{},
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"enabled": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"gateway_ip": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Validators: []validator.String{
+					ipAddressValidator{},
+					stringvalidator.AlsoRequires(path.MatchRoot("subnet")),
+				},
+			},
+			"igmp_snooping": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"ipv6_interface_type": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("none", "pd", "static"),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"network_isolation_enabled": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"purpose": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("corporate", "guest", "vlan-only", "wan"),
+				},
+			},
+			"site": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"subnet": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Validators: []validator.String{
+					cidrValidator{},
+					stringvalidator.AlsoRequires(path.MatchRoot("gateway_ip")),
+				},
+			},
+			"vlan_id": schema.Int32Attribute{
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.Between(1, 4094),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the addresses of the network are consistent with each other.
+func (r *networkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config networkResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Purpose.ValueString() == "vlan-only" && config.VLANID.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("vlan_id"),
+			"Missing VLAN ID",
+			"A VLAN ID is required for networks with a purpose of 'vlan-only'.",
+		)
+	}
+
+	// the gateway and the DHCP range must be within the subnet - malformed values are reported by the validators
+	if config.Subnet.IsNull() || config.Subnet.IsUnknown() {
+		return
+	}
+	prefix, err := netip.ParsePrefix(config.Subnet.ValueString())
+	if err != nil {
+		return
+	}
+	for _, address := range []struct {
+		name  string
+		value types.String
+	}{
+		{"gateway_ip", config.GatewayIP},
+		{"dhcp_start", config.DHCPStart},
+		{"dhcp_stop", config.DHCPStop},
+	} {
+		if address.value.IsNull() || address.value.IsUnknown() {
+			continue
+		}
+		if addr, err := netip.ParseAddr(address.value.ValueString()); err == nil && !prefix.Contains(addr) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(address.name),
+				"Address Outside of Subnet",
+				fmt.Sprintf("The address %s is not within the subnet %s of the network.", addr, prefix.Masked()),
+			)
+		}
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *networkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan networkResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// generate API request body from plan - new networks are enabled unless stated otherwise
+	network := api.Network{
+		Enabled: true,
+	}
+	resp.Diagnostics.Append(plan.applyTo(ctx, &network)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the network
+	site := siteFromModel(plan.Site, r.client)
+	createdNetwork, err := r.client.CreateNetwork(ctx, site, network)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Create Network",
+			fmt.Sprintf("Failed to create network using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	resp.Diagnostics.Append(plan.readFrom(ctx, createdNetwork)...)
+	plan.Site = types.StringValue(site)
+
+	// save state with the populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *networkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// retrieve the current state
+	var state networkResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// refresh value from the API
+	site := siteFromModel(state.Site, r.client)
+	network, err := r.client.GetNetwork(ctx, site, state.ID.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		// the network was removed outside of Terraform so drop it from the state to have it recreated
+		tflog.Warn(ctx, "network no longer exists - removing from state", map[string]any{
+			"id":   state.ID.ValueString(),
+			"site": site,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve Network",
+			fmt.Sprintf("Failed to retrieve the network with the ID '%s': %s",
+				state.ID.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// update the state
+	resp.Diagnostics.Append(state.readFrom(ctx, network)...)
+	state.Site = types.StringValue(site)
+
+	// set the refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *networkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan networkResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// start from the current network so fields not managed by Terraform are preserved
+	site := siteFromModel(plan.Site, r.client)
+	network, err := r.client.GetNetwork(ctx, site, plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve Network",
+			fmt.Sprintf("Failed to retrieve the network with the ID '%s': %s",
+				plan.ID.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// generate API request body from plan
+	resp.Diagnostics.Append(plan.applyTo(ctx, &network)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the network
+	updatedNetwork, err := r.client.UpdateNetwork(ctx, site, plan.ID.ValueString(), network)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Update Network",
+			fmt.Sprintf("Failed to update network using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	resp.Diagnostics.Append(plan.readFrom(ctx, updatedNetwork)...)
+	plan.Site = types.StringValue(site)
+
+	// save state with the populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *networkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// retrieve the current state
+	var state networkResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the network
+	if err := r.client.DeleteNetwork(ctx, siteFromModel(state.Site, r.client), state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Delete Network",
+			fmt.Sprintf("Failed to delete network using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+}
+
+// ModifyPlan sets the planned site of the network and replaces the network when it is moved to another site.
+func (r *networkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifySitePlan(ctx, r.client, req, resp)
+}
+
+// ImportState imports a network by its ID or name, optionally prefixed by its site (ie: "site/id" or "site/name").
+func (r *networkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	site, id, ok := parseImportID(r.client, req, resp)
+	if !ok {
+		return
+	}
+
+	// look up the ID of the network if it was imported by its name
+	if !isObjectID(id) {
+		networks, err := r.client.GetNetworks(ctx, site)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"UDM API: Failed to Retrieve Networks",
+				fmt.Sprintf("Failed to retrieve networks from the UDM API:\n\t%s", apiErrorDetail(err)),
+			)
+			return
+		}
+		name := id
+		id = ""
+		for _, network := range networks {
+			if network.Name == name {
+				id = network.ID
+				break
+			}
+		}
+		if id == "" {
+			resp.Diagnostics.AddError(
+				"Network Not Found",
+				fmt.Sprintf("No network named '%s' exists in the site '%s'.", name, site),
+			)
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), site)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
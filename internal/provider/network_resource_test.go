@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestAccNetworkResource(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCollectionEmpty(server, udmmock.DefaultSite, udmmock.CollectionNetworks),
+		Steps: []resource.TestStep{
+			// create and read
+			{
+				Config: providerConfig + `
+resource "udm_network" "test" {
+  name             = "IoT"
+  purpose          = "corporate"
+  vlan_id          = 10
+  subnet           = "192.168.10.0/24"
+  gateway_ip       = "192.168.10.1"
+  dhcp_enabled     = true
+  dhcp_start       = "192.168.10.6"
+  dhcp_stop        = "192.168.10.254"
+  dhcp_lease_time  = 3600
+  dhcp_dns_servers = ["1.1.1.1", "9.9.9.9"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("udm_network.test", "id"),
+					resource.TestCheckResourceAttr("udm_network.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("udm_network.test", "dhcp_dns_servers.#", "2"),
+					resource.TestCheckResourceAttr("udm_network.test", "dhcp_dns_servers.1", "9.9.9.9"),
+					resource.TestCheckResourceAttr("udm_network.test", "site", udmmock.DefaultSite),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionNetworks,
+						"udm_network.test", "ip_subnet", "192.168.10.1/24"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionNetworks,
+						"udm_network.test", "vlan_enabled", true),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionNetworks,
+						"udm_network.test", "dhcpd_dns_2", "9.9.9.9"),
+				),
+			},
+			// import by ID
+			{
+				ResourceName:      "udm_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// import by name
+			{
+				ResourceName:      "udm_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return "default/IoT", nil
+				},
+			},
+			// update and read
+			{
+				Config: providerConfig + `
+resource "udm_network" "test" {
+  name                      = "IoT Devices"
+  purpose                   = "corporate"
+  vlan_id                   = 20
+  subnet                    = "192.168.20.0/24"
+  gateway_ip                = "192.168.20.1"
+  dhcp_enabled              = true
+  dhcp_start                = "192.168.20.100"
+  dhcp_stop                 = "192.168.20.200"
+  dhcp_dns_servers          = []
+  network_isolation_enabled = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_network.test", "name", "IoT Devices"),
+					resource.TestCheckResourceAttr("udm_network.test", "dhcp_dns_servers.#", "0"),
+					resource.TestCheckResourceAttr("udm_network.test", "dhcp_lease_time", "3600"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionNetworks,
+						"udm_network.test", "ip_subnet", "192.168.20.1/24"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionNetworks,
+						"udm_network.test", "dhcpd_dns_enabled", false),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionNetworks,
+						"udm_network.test", "network_isolation_enabled", true),
+				),
+			},
+			// recreate after the network was deleted outside of Terraform
+			{
+				PreConfig: func() {
+					testAccDeleteAllObjects(server, udmmock.DefaultSite, udmmock.CollectionNetworks)
+				},
+				Config: providerConfig + `
+resource "udm_network" "test" {
+  name    = "IoT Devices"
+  purpose = "vlan-only"
+  vlan_id = 20
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionNetworks,
+						"udm_network.test", "purpose", "vlan-only"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNetworkResourcePurposeChange(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCollectionEmpty(server, udmmock.DefaultSite, udmmock.CollectionNetworks),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "udm_network" "test" {
+  name    = "Uplink"
+  purpose = "wan"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionNetworks,
+						"udm_network.test", "vlan_enabled", false),
+				),
+			},
+			// the network group of a network cannot be changed so a new network is created
+			{
+				Config: providerConfig + `
+resource "udm_network" "test" {
+  name    = "Uplink"
+  purpose = "vlan-only"
+  vlan_id = 30
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("udm_network.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionNetworks,
+						"udm_network.test", "networkgroup", "LAN"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionNetworks,
+						"udm_network.test", "vlan_enabled", true),
+				),
+			},
+			// the VLAN is only known once the resource it comes from has been applied
+			{
+				Config: providerConfig + `
+resource "terraform_data" "vlan" {
+  input = 40
+}
+
+resource "udm_network" "test" {
+  name    = "Uplink"
+  purpose = "vlan-only"
+  vlan_id = terraform_data.vlan.output
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_network.test", "vlan_id", "40"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionNetworks,
+						"udm_network.test", "vlan", float64(40)),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionNetworks,
+						"udm_network.test", "vlan_enabled", true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNetworkResourceValidation(t *testing.T) {
+	_, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "udm_network" "test" {
+  name       = "IoT"
+  purpose    = "corporate"
+  subnet     = "192.168.10.0/24"
+  gateway_ip = "192.168.20.1"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Address Outside of Subnet`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_network" "test" {
+  name    = "IoT"
+  purpose = "vlan-only"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing VLAN ID`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_network" "test" {
+  name       = "IoT"
+  purpose    = "corporate"
+  subnet     = "192.168.10.0/33"
+  gateway_ip = "192.168.10.1"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Subnet`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_network" "test" {
+  name       = "IoT"
+  purpose    = "corporate"
+  subnet     = "192.168.10.1/24"
+  gateway_ip = "192.168.10.1"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`without any host bits set \(eg:\s+192.168.10.0/24\)`),
+			},
+		},
+	})
+}
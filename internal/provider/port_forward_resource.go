@@ -0,0 +1,455 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &portForwardResource{}
+	_ resource.ResourceWithConfigure      = &portForwardResource{}
+	_ resource.ResourceWithImportState    = &portForwardResource{}
+	_ resource.ResourceWithModifyPlan     = &portForwardResource{}
+	_ resource.ResourceWithValidateConfig = &portForwardResource{}
+)
+
+// NewPortForwardResource is a helper function to simplify the provider implementation.
+func NewPortForwardResource() resource.Resource {
+	return &portForwardResource{}
+}
+
+// portForwardResource is the resource implementation.
+type portForwardResource struct {
+	client *api.Client
+}
+
+type portForwardResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	DstPort            types.String `tfsdk:"dst_port"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	ForwardIP          types.String `tfsdk:"forward_ip"`
+	ForwardPort        types.String `tfsdk:"forward_port"`
+	Interface          types.String `tfsdk:"interface"`
+	Logging            types.Bool   `tfsdk:"logging"`
+	Name               types.String `tfsdk:"name"`
+	Protocol           types.String `tfsdk:"protocol"`
+	Site               types.String `tfsdk:"site"`
+	SrcAddress         types.String `tfsdk:"src_address"`
+	SrcFirewallGroupID types.String `tfsdk:"src_firewall_group_id"`
+}
+
+// applyTo copies the values set in the model to a port forward, leaving the fields of any unset computed values
+// untouched.
+func (m portForwardResourceModel) applyTo(portForward *api.PortForward) {
+	portForward.DstPort = m.DstPort.ValueString()
+	portForward.Fwd = m.ForwardIP.ValueString()
+	portForward.Name = m.Name.ValueString()
+
+	// traffic is forwarded to the same ports it was received on unless stated otherwise
+	portForward.FwdPort = portForward.DstPort
+	if !m.ForwardPort.IsNull() && !m.ForwardPort.IsUnknown() {
+		portForward.FwdPort = m.ForwardPort.ValueString()
+	}
+
+	// the UDM accepts traffic from anywhere unless it is limited to an address or the members of a firewall group
+	portForward.Src = "any"
+	portForward.SrcFirewallGroupID = ""
+	portForward.SrcLimitingEnabled = false
+	portForward.SrcLimitingType = ""
+	switch {
+	case m.SrcAddress.ValueString() != "":
+		portForward.Src = m.SrcAddress.ValueString()
+		portForward.SrcLimitingEnabled = true
+		portForward.SrcLimitingType = "ip"
+	case m.SrcFirewallGroupID.ValueString() != "":
+		portForward.SrcFirewallGroupID = m.SrcFirewallGroupID.ValueString()
+		portForward.SrcLimitingEnabled = true
+		portForward.SrcLimitingType = "firewall_group"
+	}
+
+	if !m.Enabled.IsNull() && !m.Enabled.IsUnknown() {
+		portForward.Enabled = m.Enabled.ValueBool()
+	}
+	if !m.Interface.IsNull() && !m.Interface.IsUnknown() {
+		portForward.PortForwardIface = m.Interface.ValueString()
+	}
+	if !m.Logging.IsNull() && !m.Logging.IsUnknown() {
+		portForward.Log = m.Logging.ValueBool()
+	}
+	if !m.Protocol.IsNull() && !m.Protocol.IsUnknown() {
+		portForward.Protocol = m.Protocol.ValueString()
+	}
+}
+
+// readFrom sets the model to the values of a port forward.
+func (m *portForwardResourceModel) readFrom(portForward api.PortForward) {
+	m.ID = types.StringValue(portForward.ID)
+	m.DstPort = types.StringValue(portForward.DstPort)
+	m.Enabled = types.BoolValue(portForward.Enabled)
+	m.ForwardIP = types.StringValue(portForward.Fwd)
+	m.ForwardPort = types.StringValue(portForward.FwdPort)
+	m.Interface = types.StringValue(portForward.PortForwardIface)
+	m.Logging = types.BoolValue(portForward.Log)
+	m.Name = types.StringValue(portForward.Name)
+	m.Protocol = types.StringValue(portForward.Protocol)
+	m.SrcAddress = types.StringNull()
+	m.SrcFirewallGroupID = types.StringNull()
+	if portForward.SrcLimitingEnabled {
+		switch portForward.SrcLimitingType {
+		case "ip":
+			m.SrcAddress = optionalString(portForward.Src)
+		case "firewall_group":
+			m.SrcFirewallGroupID = optionalString(portForward.SrcFirewallGroupID)
+		}
+	}
+}
+
+// portCount returns the number of ports in a port, a range of ports or a comma-separated list of both which has
+// already been validated.
+func portCount(s string) int {
+	count := 0
+	for _, item := range strings.Split(s, ",") {
+		first, last, isRange := strings.Cut(item, "-")
+		if !isRange {
+			count++
+			continue
+		}
+		start, _ := parsePort(first)
+		end, _ := parsePort(last)
+		count += end - start + 1
+	}
+	return count
+}
+
+func (r *portForwardResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *portForwardResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_port_forward"
+}
+
+// Schema defines the schema for the resource.
+func (r *portForwardResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dst_port": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					portsValidator{},
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"forward_ip": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					ipAddressValidator{},
+				},
+			},
+			"forward_port": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Validators: []validator.String{
+					portsValidator{},
+				},
+			},
+			"interface": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("wan", "wan2", "both"),
+				},
+			},
+			"logging": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"protocol": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("tcp_udp", "tcp", "udp"),
+				},
+			},
+			"site": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"src_address": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.Any(ipAddressValidator{}, cidrValidator{}),
+					stringvalidator.ConflictsWith(path.MatchRoot("src_firewall_group_id")),
+				},
+			},
+			"src_firewall_group_id": schema.StringAttribute{
+				Optional: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that traffic is forwarded to as many ports as it is received on.
+func (r *portForwardResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config portForwardResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// malformed ports are reported by the validators
+	dstPort, forwardPort := config.DstPort.ValueString(), config.ForwardPort.ValueString()
+	if dstPort == "" || forwardPort == "" || validatePorts(dstPort) != nil || validatePorts(forwardPort) != nil {
+		return
+	}
+	if portCount(dstPort) != portCount(forwardPort) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("forward_port"),
+			"Port Range Mismatch",
+			fmt.Sprintf("The forward port '%s' does not cover as many ports as the destination port '%s'.", forwardPort,
+				dstPort),
+		)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *portForwardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan portForwardResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// generate API request body from plan - new port forwards are enabled and forward TCP and UDP traffic received on
+	// the primary WAN interface unless stated otherwise
+	portForward := api.PortForward{
+		Enabled:          true,
+		PortForwardIface: "wan",
+		Protocol:         "tcp_udp",
+	}
+	plan.applyTo(&portForward)
+
+	// create the port forward
+	site := siteFromModel(plan.Site, r.client)
+	createdPortForward, err := r.client.CreatePortForward(ctx, site, portForward)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Create Port Forward",
+			fmt.Sprintf("Failed to create port forward using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	plan.readFrom(createdPortForward)
+	plan.Site = types.StringValue(site)
+
+	// save state with the populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *portForwardResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// retrieve the current state
+	var state portForwardResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// refresh value from the API
+	site := siteFromModel(state.Site, r.client)
+	portForward, err := r.client.GetPortForward(ctx, site, state.ID.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		// the port forward was removed outside of Terraform so drop it from the state to have it recreated
+		tflog.Warn(ctx, "port forward no longer exists - removing from state", map[string]any{
+			"id":   state.ID.ValueString(),
+			"site": site,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve Port Forward",
+			fmt.Sprintf("Failed to retrieve the port forward with the ID '%s': %s",
+				state.ID.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// update the state
+	state.readFrom(portForward)
+	state.Site = types.StringValue(site)
+
+	// set the refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *portForwardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan portForwardResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// start from the current port forward so fields not managed by Terraform are preserved
+	site := siteFromModel(plan.Site, r.client)
+	portForward, err := r.client.GetPortForward(ctx, site, plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve Port Forward",
+			fmt.Sprintf("Failed to retrieve the port forward with the ID '%s': %s",
+				plan.ID.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// generate API request body from plan
+	plan.applyTo(&portForward)
+
+	// update the port forward
+	updatedPortForward, err := r.client.UpdatePortForward(ctx, site, plan.ID.ValueString(), portForward)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Update Port Forward",
+			fmt.Sprintf("Failed to update port forward using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	plan.readFrom(updatedPortForward)
+	plan.Site = types.StringValue(site)
+
+	// save state with the populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *portForwardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// retrieve the current state
+	var state portForwardResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the port forward
+	if err := r.client.DeletePortForward(ctx, siteFromModel(state.Site, r.client), state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Delete Port Forward",
+			fmt.Sprintf("Failed to delete port forward using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+}
+
+// ModifyPlan sets the planned site of the port forward and replaces the port forward when it is moved to another site.
+func (r *portForwardResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifySitePlan(ctx, r.client, req, resp)
+}
+
+// ImportState imports a port forward by its ID or name, optionally prefixed by its site (ie: "site/id" or
+// "site/name").
+func (r *portForwardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	site, id, ok := parseImportID(r.client, req, resp)
+	if !ok {
+		return
+	}
+
+	// look up the ID of the port forward if it was imported by its name
+	if !isObjectID(id) {
+		portForwards, err := r.client.GetPortForwards(ctx, site)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"UDM API: Failed to Retrieve Port Forwards",
+				fmt.Sprintf("Failed to retrieve port forwards from the UDM API:\n\t%s", apiErrorDetail(err)),
+			)
+			return
+		}
+		name := id
+		id = ""
+		for _, portForward := range portForwards {
+			if portForward.Name == name {
+				id = portForward.ID
+				break
+			}
+		}
+		if id == "" {
+			resp.Diagnostics.AddError(
+				"Port Forward Not Found",
+				fmt.Sprintf("No port forward named '%s' exists in the site '%s'.", name, site),
+			)
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), site)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
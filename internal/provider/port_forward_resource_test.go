@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestAccPortForwardResource(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckCollectionEmpty(server, udmmock.DefaultSite, udmmock.CollectionPortForwards),
+			testAccCheckCollectionEmpty(server, udmmock.DefaultSite, udmmock.CollectionFirewallGroups),
+		),
+		Steps: []resource.TestStep{
+			// create and read
+			{
+				Config: providerConfig + `
+resource "udm_port_forward" "test" {
+  name       = "NAS"
+  dst_port   = "8443"
+  forward_ip = "192.168.1.20"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("udm_port_forward.test", "id"),
+					resource.TestCheckResourceAttr("udm_port_forward.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("udm_port_forward.test", "forward_port", "8443"),
+					resource.TestCheckResourceAttr("udm_port_forward.test", "interface", "wan"),
+					resource.TestCheckResourceAttr("udm_port_forward.test", "logging", "false"),
+					resource.TestCheckResourceAttr("udm_port_forward.test", "protocol", "tcp_udp"),
+					resource.TestCheckNoResourceAttr("udm_port_forward.test", "src_address"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionPortForwards,
+						"udm_port_forward.test", "src", "any"),
+				),
+			},
+			// import by name
+			{
+				ResourceName:      "udm_port_forward.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return "NAS", nil
+				},
+			},
+			// limit the sources to an address and forward to another port
+			{
+				Config: providerConfig + `
+resource "udm_port_forward" "test" {
+  name         = "NAS"
+  dst_port     = "8443"
+  forward_ip   = "192.168.1.20"
+  forward_port = "443"
+  interface    = "both"
+  protocol     = "tcp"
+  logging      = true
+  src_address  = "198.51.100.0/24"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_port_forward.test", "forward_port", "443"),
+					resource.TestCheckResourceAttr("udm_port_forward.test", "src_address", "198.51.100.0/24"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionPortForwards,
+						"udm_port_forward.test", "src_limiting_type", "ip"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionPortForwards,
+						"udm_port_forward.test", "log", true),
+				),
+			},
+			// limit the sources to a firewall group and forward a range of ports
+			{
+				Config: providerConfig + `
+resource "udm_firewall_group" "office" {
+  name    = "Office"
+  type    = "address-group"
+  members = ["198.51.100.0/24", "203.0.113.7"]
+}
+
+resource "udm_port_forward" "test" {
+  name                  = "NAS"
+  dst_port              = "8000-8010"
+  forward_ip            = "192.168.1.21"
+  forward_port          = "9000-9010"
+  enabled               = false
+  src_firewall_group_id = udm_firewall_group.office.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_port_forward.test", "enabled", "false"),
+					resource.TestCheckNoResourceAttr("udm_port_forward.test", "src_address"),
+					resource.TestCheckResourceAttrPair("udm_port_forward.test", "src_firewall_group_id",
+						"udm_firewall_group.office", "id"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionPortForwards,
+						"udm_port_forward.test", "src", "any"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionPortForwards,
+						"udm_port_forward.test", "src_limiting_type", "firewall_group"),
+				),
+			},
+			// recreate after the port forward was deleted outside of Terraform
+			{
+				PreConfig: func() {
+					testAccDeleteAllObjects(server, udmmock.DefaultSite, udmmock.CollectionPortForwards)
+				},
+				Config: providerConfig + `
+resource "udm_port_forward" "test" {
+  name       = "Game server"
+  dst_port   = "25565"
+  forward_ip = "192.168.1.30"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionPortForwards,
+						"udm_port_forward.test", "fwd_port", "25565"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPortForwardResourceValidation(t *testing.T) {
+	_, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "udm_port_forward" "test" {
+  name         = "NAS"
+  dst_port     = "8000-8010"
+  forward_ip   = "192.168.1.20"
+  forward_port = "443"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Port Range Mismatch`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_port_forward" "test" {
+  name                  = "NAS"
+  dst_port              = "8443"
+  forward_ip            = "192.168.1.20"
+  src_address           = "198.51.100.7"
+  src_firewall_group_id = "000000000000000000000001"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_port_forward" "test" {
+  name       = "NAS"
+  dst_port   = "8443"
+  forward_ip = "nas.example.com"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid IP Address`),
+			},
+		},
+	})
+}
@@ -5,7 +5,10 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
@@ -14,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
 )
@@ -34,11 +38,23 @@ type udmProvider struct {
 
 // udmProviderModel describes the provider data model.
 type udmProviderModel struct {
-	Hostname                      types.String `tfsdk:"hostname"`
-	IgnoreUntrustedSSLCertificate types.Bool   `tfsdk:"ignore_untrusted_ssl_certificate"`
-	Password                      types.String `tfsdk:"password"`
-	Site                          types.String `tfsdk:"site"`
-	Username                      types.String `tfsdk:"username"`
+	APIKey                        types.String  `tfsdk:"api_key"`
+	CACertificateFile             types.String  `tfsdk:"ca_certificate_file"`
+	CACertificatePEM              types.String  `tfsdk:"ca_certificate_pem"`
+	CredentialsFile               types.String  `tfsdk:"credentials_file"`
+	Hostname                      types.String  `tfsdk:"hostname"`
+	IgnoreUntrustedSSLCertificate types.Bool    `tfsdk:"ignore_untrusted_ssl_certificate"`
+	MaxConcurrentRequests         types.Int64   `tfsdk:"max_concurrent_requests"`
+	MaxRequestsPerSecond          types.Float64 `tfsdk:"max_requests_per_second"`
+	MFAToken                      types.String  `tfsdk:"mfa_token"`
+	Password                      types.String  `tfsdk:"password"`
+	Profile                       types.String  `tfsdk:"profile"`
+	RetryMaxAttempts              types.Int64   `tfsdk:"retry_max_attempts"`
+	RetryMaxWait                  types.String  `tfsdk:"retry_max_wait"`
+	Site                          types.String  `tfsdk:"site"`
+	TLSFingerprintSHA256          types.String  `tfsdk:"tls_fingerprint_sha256"`
+	TOTPSecret                    types.String  `tfsdk:"totp_secret"`
+	Username                      types.String  `tfsdk:"username"`
 }
 
 func (p *udmProvider) Metadata(ctx context.Context, req provider.MetadataRequest,
@@ -53,10 +69,38 @@ func (p *udmProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"api_key": schema.StringAttribute{
+				Description: "UniFi OS API key to use for authentication instead of a username and password",
+				MarkdownDescription: "UniFi OS API key to use for authentication instead of a `username` and " +
+					"`password`",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"ca_certificate_file": schema.StringAttribute{
+				Description: "Path to a file with the PEM-encoded CA certificates to trust instead of the " +
+					"system roots when verifying the UDM host's certificate",
+				MarkdownDescription: "Path to a file with the PEM-encoded CA certificates to trust instead of the " +
+					"system roots when verifying the UDM host's certificate",
+				Optional: true,
+			},
+			"ca_certificate_pem": schema.StringAttribute{
+				Description: "PEM-encoded CA certificates to trust instead of the system roots when verifying " +
+					"the UDM host's certificate",
+				MarkdownDescription: "PEM-encoded CA certificates to trust instead of the system roots when verifying " +
+					"the UDM host's certificate",
+				Optional: true,
+			},
+			"credentials_file": schema.StringAttribute{
+				Description: "Path to an INI or YAML credentials file with named profiles; files ending in " +
+					"'.yaml' or '.yml' are parsed as YAML (defaults to '~/.udm/credentials')",
+				MarkdownDescription: "Path to an INI or YAML credentials file with named profiles; files ending in " +
+					"`.yaml` or `.yml` are parsed as YAML (defaults to `~/.udm/credentials`)",
+				Optional: true,
+			},
 			"hostname": schema.StringAttribute{
 				Description:         "UDM hostname or IP address",
 				MarkdownDescription: "UDM hostname or IP address",
-				Required:            true,
+				Optional:            true,
 				//Validators:          []validator.String{},
 			},
 			"ignore_untrusted_ssl_certificate": schema.BoolAttribute{
@@ -64,23 +108,79 @@ func (p *udmProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				MarkdownDescription: "Ignore any untrusted / self-signed certificate from the UDM host",
 				Optional:            true,
 			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of requests sent to the UDM at once, where 0 means no limit "+
+					"(uses %d if not supplied)", api.DefaultMaxConcurrentRequests),
+				MarkdownDescription: fmt.Sprintf("Maximum number of requests sent to the UDM at once, where `0` means "+
+					"no limit (uses `%d` if not supplied)", api.DefaultMaxConcurrentRequests),
+				Optional: true,
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				Description: fmt.Sprintf("Maximum number of requests sent to the UDM per second, where 0 means no "+
+					"limit (uses %d if not supplied)", api.DefaultMaxRequestsPerSecond),
+				MarkdownDescription: fmt.Sprintf("Maximum number of requests sent to the UDM per second, where `0` "+
+					"means no limit (uses `%d` if not supplied)", api.DefaultMaxRequestsPerSecond),
+				Optional: true,
+			},
+			"mfa_token": schema.StringAttribute{
+				Description: "One-time multi-factor authentication code for accounts with MFA enabled " +
+					"(the session cannot be refreshed once it expires - use 'totp_secret' for long runs)",
+				MarkdownDescription: "One-time multi-factor authentication code for accounts with MFA enabled " +
+					"(the session cannot be refreshed once it expires - use `totp_secret` for long runs)",
+				Optional:  true,
+				Sensitive: true,
+			},
 			"password": schema.StringAttribute{
-				Description:         "UDM password to use for authentication",
-				MarkdownDescription: "UDM password to use for authentication",
-				Required:            true,
+				Description:         "UDM password to use for authentication (required unless 'api_key' is set)",
+				MarkdownDescription: "UDM password to use for authentication (required unless `api_key` is set)",
+				Optional:            true,
 				Sensitive:           true,
 				//Validators:          []validator.String{},
 			},
+			"profile": schema.StringAttribute{
+				Description:         "Name of the profile to use from the credentials file (uses 'default' if not supplied)",
+				MarkdownDescription: "Name of the profile to use from the credentials file (uses `default` if not supplied)",
+				Optional:            true,
+			},
+			"retry_max_attempts": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of attempts made for a request which fails with a transient "+
+					"error, including the first one (uses %d if not supplied)", api.DefaultRetryMaxAttempts),
+				MarkdownDescription: fmt.Sprintf("Maximum number of attempts made for a request which fails with a "+
+					"transient error, including the first one (uses `%d` if not supplied)", api.DefaultRetryMaxAttempts),
+				Optional: true,
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Description: fmt.Sprintf("Maximum time to wait between attempts of a failed request as a duration "+
+					"such as '30s' or '1m' (uses '%s' if not supplied)", api.DefaultRetryMaxWait),
+				MarkdownDescription: fmt.Sprintf("Maximum time to wait between attempts of a failed request as a "+
+					"duration such as `30s` or `1m` (uses `%s` if not supplied)", api.DefaultRetryMaxWait),
+				Optional: true,
+			},
 			"site": schema.StringAttribute{
-				Description:         "Name of the UDM site (uses 'default' if not supplied)",
-				MarkdownDescription: "Name of the UDM site (uses 'default' if not supplied)",
+				Description:         "Name of the UDM site managed by default (uses 'default' if not supplied)",
+				MarkdownDescription: "Name of the UDM site managed by default (uses 'default' if not supplied)",
 				Optional:            true,
 				//Validators:          []validator.String{},
 			},
+			"tls_fingerprint_sha256": schema.StringAttribute{
+				Description: "SHA-256 fingerprint the UDM host's certificate must match (eg: 'AB:CD:...'); " +
+					"without a CA certificate this trusts exactly that certificate, including a self-signed one",
+				MarkdownDescription: "SHA-256 fingerprint the UDM host's certificate must match (eg: `AB:CD:...`); " +
+					"without a CA certificate this trusts exactly that certificate, including a self-signed one",
+				Optional: true,
+			},
+			"totp_secret": schema.StringAttribute{
+				Description: "Base32-encoded TOTP secret used to generate multi-factor authentication codes " +
+					"for accounts with MFA enabled",
+				MarkdownDescription: "Base32-encoded TOTP secret used to generate multi-factor authentication codes " +
+					"for accounts with MFA enabled",
+				Optional:  true,
+				Sensitive: true,
+			},
 			"username": schema.StringAttribute{
-				Description:         "UDM username to use for authentication",
-				MarkdownDescription: "UDM username to use for authentication",
-				Required:            true,
+				Description:         "UDM username to use for authentication (required unless 'api_key' is set)",
+				MarkdownDescription: "UDM username to use for authentication (required unless `api_key` is set)",
+				Optional:            true,
 				Sensitive:           true,
 				//Validators:          []validator.String{},
 			},
@@ -100,6 +200,51 @@ func (p *udmProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	}
 
 	// if the caller provided a configuration value for any of the attributes, it must be a known value
+	if config.CACertificateFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_certificate_file"),
+			"Unknown UDM API CA Certificate File",
+			"The provider cannot create the UDM API client as there is an unknown configuration value for "+
+				"the UDM API CA certificate file. Either target apply the source of the value first, set the value "+
+				"statically in the configuration, or use a variable in the configuration.",
+		)
+	}
+	if config.CACertificatePEM.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_certificate_pem"),
+			"Unknown UDM API CA Certificate",
+			"The provider cannot create the UDM API client as there is an unknown configuration value for "+
+				"the UDM API CA certificate. Either target apply the source of the value first, set the value "+
+				"statically in the configuration, or use a variable in the configuration.",
+		)
+	}
+	if config.CredentialsFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credentials_file"),
+			"Unknown UDM API Credentials File",
+			"The provider cannot create the UDM API client as there is an unknown configuration value for "+
+				"the UDM API credentials file. Either target apply the source of the value first, set the value "+
+				"statically in the configuration, or use a variable in the configuration.",
+		)
+	}
+	if config.Profile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown UDM API Credentials Profile",
+			"The provider cannot create the UDM API client as there is an unknown configuration value for "+
+				"the UDM API credentials profile. Either target apply the source of the value first, set the value "+
+				"statically in the configuration, or use a variable in the configuration.",
+		)
+	}
+	if config.APIKey.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key"),
+			"Unknown UDM API Key",
+			"The provider cannot create the UDM API client as there is an unknown configuration value for "+
+				"the UDM API key. Either target apply the source of the value first, set the value "+
+				"statically in the configuration, or use a variable in the configuration.",
+		)
+	}
 	if config.Hostname.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("hostname"),
@@ -109,6 +254,33 @@ func (p *udmProvider) Configure(ctx context.Context, req provider.ConfigureReque
 				"statically in the configuration, or use a variable in the configuration.",
 		)
 	}
+	if config.MaxConcurrentRequests.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Unknown UDM API Maximum Concurrent Requests",
+			"The provider cannot create the UDM API client as there is an unknown configuration value for "+
+				"the UDM API maximum concurrent requests. Either target apply the source of the value first, set the "+
+				"value statically in the configuration, or use a variable in the configuration.",
+		)
+	}
+	if config.MaxRequestsPerSecond.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_requests_per_second"),
+			"Unknown UDM API Maximum Requests Per Second",
+			"The provider cannot create the UDM API client as there is an unknown configuration value for "+
+				"the UDM API maximum requests per second. Either target apply the source of the value first, set the "+
+				"value statically in the configuration, or use a variable in the configuration.",
+		)
+	}
+	if config.MFAToken.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("mfa_token"),
+			"Unknown UDM API MFA Token",
+			"The provider cannot create the UDM API client as there is an unknown configuration value for "+
+				"the UDM API MFA token. Either target apply the source of the value first, set the value "+
+				"statically in the configuration, or use a variable in the configuration.",
+		)
+	}
 	if config.Password.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("password"),
@@ -118,6 +290,24 @@ func (p *udmProvider) Configure(ctx context.Context, req provider.ConfigureReque
 				"statically in the configuration, or use a variable in the configuration.",
 		)
 	}
+	if config.RetryMaxAttempts.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_max_attempts"),
+			"Unknown UDM API Retry Maximum Attempts",
+			"The provider cannot create the UDM API client as there is an unknown configuration value for "+
+				"the UDM API retry maximum attempts. Either target apply the source of the value first, set the value "+
+				"statically in the configuration, or use a variable in the configuration.",
+		)
+	}
+	if config.RetryMaxWait.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_max_wait"),
+			"Unknown UDM API Retry Maximum Wait",
+			"The provider cannot create the UDM API client as there is an unknown configuration value for "+
+				"the UDM API retry maximum wait. Either target apply the source of the value first, set the value "+
+				"statically in the configuration, or use a variable in the configuration.",
+		)
+	}
 	if config.Site.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("site"),
@@ -127,6 +317,24 @@ func (p *udmProvider) Configure(ctx context.Context, req provider.ConfigureReque
 				"statically in the configuration, or use a variable in the configuration.",
 		)
 	}
+	if config.TLSFingerprintSHA256.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls_fingerprint_sha256"),
+			"Unknown UDM API TLS Fingerprint",
+			"The provider cannot create the UDM API client as there is an unknown configuration value for "+
+				"the UDM API TLS fingerprint. Either target apply the source of the value first, set the value "+
+				"statically in the configuration, or use a variable in the configuration.",
+		)
+	}
+	if config.TOTPSecret.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("totp_secret"),
+			"Unknown UDM API TOTP Secret",
+			"The provider cannot create the UDM API client as there is an unknown configuration value for "+
+				"the UDM API TOTP secret. Either target apply the source of the value first, set the value "+
+				"statically in the configuration, or use a variable in the configuration.",
+		)
+	}
 	if config.Username.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("username"),
@@ -140,51 +348,295 @@ func (p *udmProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
+	// load the credentials file profile - a missing file or profile is only an error if it was explicitly set
+	credentialsFile := resolveString(config.CredentialsFile, "UDM_CREDENTIALS_FILE", nil, "")
+	explicitCredentialsFile := credentialsFile != ""
+	if !explicitCredentialsFile {
+		credentialsFile = defaultCredentialsFile()
+	}
+	profileName := resolveString(config.Profile, "UDM_PROFILE", nil, "")
+	explicitProfile := profileName != ""
+	if !explicitProfile {
+		profileName = defaultCredentialsProfile
+	}
+	var profile credentialsProfile
+	if credentialsFile != "" {
+		var err error
+		profile, err = loadCredentialsProfile(credentialsFile, profileName)
+		switch {
+		case err == nil:
+			tflog.Debug(ctx, "loaded UDM API credentials profile", map[string]any{
+				"credentials_file": credentialsFile,
+				"profile":          profileName,
+			})
+		case errors.Is(err, os.ErrNotExist) && !explicitCredentialsFile:
+			// the default credentials file is optional
+		case errors.Is(err, errProfileNotFound) && !explicitProfile:
+			// the default profile is optional
+		case errors.Is(err, errProfileNotFound):
+			resp.Diagnostics.AddAttributeError(
+				path.Root("profile"),
+				"Missing UDM API Credentials Profile",
+				fmt.Sprintf("The provider cannot create the UDM API client as the UDM API credentials profile "+
+					"could not be found. Set the 'profile' attribute or the UDM_PROFILE environment variable to a "+
+					"profile that exists in the credentials file.\n\t%s", err.Error()),
+			)
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credentials_file"),
+				"Invalid UDM API Credentials File",
+				fmt.Sprintf("The provider cannot create the UDM API client as the UDM API credentials file "+
+					"could not be read. Set the 'credentials_file' attribute or the UDM_CREDENTIALS_FILE "+
+					"environment variable to a readable credentials file.\n\t%s", err.Error()),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// describes where a value can be set when it is missing
+	expectedIn := func(attribute, envVar string) string {
+		return fmt.Sprintf("Set the '%s' attribute in the provider configuration, the %s environment variable "+
+			"or the '%s' key of the '%s' profile in the credentials file (%s). If any of them is set already, "+
+			"ensure the value is not empty.", attribute, envVar, attribute, profileName, credentialsFile)
+	}
+
 	// if any of the configurations are missing, return errors with guidance
-	hostname := config.Hostname.ValueString()
+	hostname := resolveString(config.Hostname, "UDM_HOSTNAME", profile, "hostname")
 	if hostname == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("hostname"),
 			"Missing UDM API Host",
 			"The provider cannot create the UDM API client as there is a missing or empty value for "+
-				"the UDM API hostname. Set the value statically in the configuration or use a variable in the "+
-				"configuration. If either is set already, ensure the value is not empty.",
+				"the UDM API hostname. "+expectedIn("hostname", "UDM_HOSTNAME"),
 		)
 	}
-	password := config.Password.ValueString()
-	if password == "" {
+	ignoreUntrustedSSLCertificate, err := resolveBool(config.IgnoreUntrustedSSLCertificate, "UDM_INSECURE", profile,
+		"ignore_untrusted_ssl_certificate")
+	if err != nil {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("password"),
-			"Missing UDM API Password",
-			"The provider cannot create the UDM API client as there is a missing or empty value for "+
-				"the UDM API password. Set the value statically in the configuration or use a variable in the "+
-				"configuration. If either is set already, ensure the value is not empty.",
+			path.Root("ignore_untrusted_ssl_certificate"),
+			"Invalid UDM API Certificate Verification Setting",
+			fmt.Sprintf("The provider cannot create the UDM API client as the setting to ignore untrusted SSL "+
+				"certificates is not a valid boolean value:\n\t%s", err.Error()),
+		)
+	}
+	caCertificatePEM := resolveString(config.CACertificatePEM, "UDM_CA_CERTIFICATE_PEM", profile, "ca_certificate_pem")
+	caCertificateFile := resolveString(config.CACertificateFile, "UDM_CA_CERTIFICATE_FILE", profile,
+		"ca_certificate_file")
+	if caCertificatePEM != "" && caCertificateFile != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_certificate_pem"),
+			"Conflicting UDM API CA Certificate Configuration",
+			"The provider cannot create the UDM API client as both the UDM API CA certificate and CA certificate "+
+				"file are set. Set only one of them.",
+		)
+	}
+	if caCertificateFile != "" {
+		pem, err := os.ReadFile(caCertificateFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_certificate_file"),
+				"Invalid UDM API CA Certificate File",
+				fmt.Sprintf("The provider cannot create the UDM API client as the UDM API CA certificate file "+
+					"could not be read:\n\t%s", err.Error()),
+			)
+		}
+		caCertificatePEM = string(pem)
+	}
+	tlsFingerprint := resolveString(config.TLSFingerprintSHA256, "UDM_TLS_FINGERPRINT_SHA256", profile,
+		"tls_fingerprint_sha256")
+	if tlsFingerprint != "" {
+		if _, err := api.ParseFingerprint(tlsFingerprint); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tls_fingerprint_sha256"),
+				"Invalid UDM API TLS Fingerprint",
+				fmt.Sprintf("The provider cannot create the UDM API client as the UDM API TLS fingerprint is not "+
+					"a valid SHA-256 fingerprint:\n\t%s", err.Error()),
+			)
+		}
+	}
+	if ignoreUntrustedSSLCertificate && (caCertificatePEM != "" || tlsFingerprint != "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ignore_untrusted_ssl_certificate"),
+			"Conflicting UDM API Certificate Verification Configuration",
+			"The provider cannot create the UDM API client as untrusted SSL certificates are ignored while a "+
+				"CA certificate or TLS fingerprint is also set. Remove the setting to ignore untrusted SSL "+
+				"certificates so the UDM host's certificate is verified.",
 		)
 	}
-	site := config.Site.ValueString()
+	maxConcurrentRequests := int64(api.DefaultMaxConcurrentRequests)
+	if !config.MaxConcurrentRequests.IsNull() {
+		maxConcurrentRequests = config.MaxConcurrentRequests.ValueInt64()
+		if maxConcurrentRequests < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid UDM API Maximum Concurrent Requests",
+				"The provider cannot create the UDM API client as the UDM API maximum concurrent requests cannot "+
+					"be negative. Use 0 to remove the limit.",
+			)
+		}
+	}
+	maxRequestsPerSecond := float64(api.DefaultMaxRequestsPerSecond)
+	if !config.MaxRequestsPerSecond.IsNull() {
+		maxRequestsPerSecond = config.MaxRequestsPerSecond.ValueFloat64()
+		if maxRequestsPerSecond < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_requests_per_second"),
+				"Invalid UDM API Maximum Requests Per Second",
+				"The provider cannot create the UDM API client as the UDM API maximum requests per second cannot "+
+					"be negative. Use 0 to remove the limit.",
+			)
+		}
+	}
+	retryPolicy := api.DefaultRetryPolicy()
+	if !config.RetryMaxAttempts.IsNull() {
+		retryPolicy.MaxAttempts = int(config.RetryMaxAttempts.ValueInt64())
+		if retryPolicy.MaxAttempts < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_attempts"),
+				"Invalid UDM API Retry Maximum Attempts",
+				"The provider cannot create the UDM API client as the UDM API retry maximum attempts must be at "+
+					"least 1.",
+			)
+		}
+	}
+	if !config.RetryMaxWait.IsNull() {
+		maxWait, err := time.ParseDuration(config.RetryMaxWait.ValueString())
+		if err != nil || maxWait <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_wait"),
+				"Invalid UDM API Retry Maximum Wait",
+				fmt.Sprintf("The provider cannot create the UDM API client as the UDM API retry maximum wait "+
+					"'%s' is not a positive duration such as '30s' or '1m'.", config.RetryMaxWait.ValueString()),
+			)
+		}
+		retryPolicy.MaxWait = maxWait
+		retryPolicy.MinWait = min(retryPolicy.MinWait, maxWait)
+	}
+	apiKey := resolveString(config.APIKey, "UDM_API_KEY", profile, "api_key")
+	password := resolveString(config.Password, "UDM_PASSWORD", profile, "password")
+	site := resolveString(config.Site, "UDM_SITE", profile, "site")
 	if site == "" {
 		site = api.DefaultSite
 	}
-	username := config.Username.ValueString()
-	if username == "" {
+	username := resolveString(config.Username, "UDM_USERNAME", profile, "username")
+	mfaToken := resolveString(config.MFAToken, "UDM_MFA_TOKEN", profile, "mfa_token")
+	totpSecret := resolveString(config.TOTPSecret, "UDM_TOTP_SECRET", profile, "totp_secret")
+	if apiKey != "" {
+		// an API key replaces all other credentials
+		if username != "" || password != "" || mfaToken != "" || totpSecret != "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_key"),
+				"Conflicting UDM API Credentials",
+				"The provider cannot create the UDM API client as both the UDM API key and a username, "+
+					"password, MFA token or TOTP secret are set. Either set only the API key or remove it.",
+			)
+		}
+	} else {
+		if password == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password"),
+				"Missing UDM API Password",
+				"The provider cannot create the UDM API client as there is a missing or empty value for "+
+					"the UDM API password and no UDM API key is set. "+expectedIn("password", "UDM_PASSWORD"),
+			)
+		}
+		if username == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("username"),
+				"Missing UDM API Username",
+				"The provider cannot create the UDM API client as there is a missing or empty value for "+
+					"the UDM API username and no UDM API key is set. "+expectedIn("username", "UDM_USERNAME"),
+			)
+		}
+	}
+	if mfaToken != "" && totpSecret != "" {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("username"),
-			"Missing UDM API Username",
-			"The provider cannot create the UDM API client as there is a missing or empty value for "+
-				"the UDM API username. Set the value statically in the configuration or use a variable in the "+
-				"configuration. If either is set already, ensure the value is not empty.",
+			path.Root("mfa_token"),
+			"Conflicting UDM API MFA Configuration",
+			"The provider cannot create the UDM API client as both the UDM API MFA token and TOTP secret "+
+				"are set. Set only one of them.",
+		)
+	}
+	if totpSecret != "" {
+		if _, err := api.GenerateTOTP(totpSecret, time.Now()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("totp_secret"),
+				"Invalid UDM API TOTP Secret",
+				fmt.Sprintf("The provider cannot create the UDM API client as the UDM API TOTP secret is not "+
+					"a valid base32-encoded value:\n\t%s", err.Error()),
+			)
+		}
+	}
+
+	// the exchanges with the UDM can be recorded to or replayed from a cassette file for debugging
+	recordCassette := os.Getenv("UDM_RECORD")
+	replayCassette := os.Getenv("UDM_REPLAY")
+	if recordCassette != "" && replayCassette != "" {
+		resp.Diagnostics.AddError(
+			"Conflicting UDM API Cassette Configuration",
+			"The provider cannot create the UDM API client as both the UDM_RECORD and UDM_REPLAY environment "+
+				"variables are set. Set only one of them.",
 		)
 	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if recordCassette != "" {
+		tflog.Warn(ctx, "recording UDM API requests to cassette", map[string]any{
+			"cassette": recordCassette,
+		})
+	}
+	if replayCassette != "" {
+		tflog.Warn(ctx, "replaying UDM API responses from cassette - no requests are sent to the UDM", map[string]any{
+			"cassette": replayCassette,
+		})
+	}
 
 	// create the API client
-	client := api.NewClient(hostname, site, config.IgnoreUntrustedSSLCertificate.ValueBool())
-	if err := client.Login(ctx, username, password); err != nil {
+	client, err := api.NewClient(api.ClientConfig{
+		Hostname:               hostname,
+		Site:                   site,
+		IgnoreUntrustedSSLCert: ignoreUntrustedSSLCertificate,
+		CACertificatePEM:       []byte(caCertificatePEM),
+		TLSFingerprintSHA256:   tlsFingerprint,
+		MaxRequestsPerSecond:   maxRequestsPerSecond,
+		MaxConcurrentRequests:  int(maxConcurrentRequests),
+		RetryPolicy:            &retryPolicy,
+		RecordCassette:         recordCassette,
+		ReplayCassette:         replayCassette,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Invalid Client Configuration",
+			fmt.Sprintf("Failed to create the UDM API client:\n\t%s", err.Error()),
+		)
+		return
+	}
+	if apiKey != "" {
+		err = client.UseAPIKey(ctx, apiKey)
+	} else {
+		err = client.Login(ctx, api.Credentials{
+			Username:   username,
+			Password:   password,
+			MFAToken:   mfaToken,
+			TOTPSecret: totpSecret,
+		})
+	}
+	switch {
+	case errors.Is(err, api.ErrMFARequired):
+		resp.Diagnostics.AddError(
+			"UDM API: Multi-Factor Authentication Required",
+			fmt.Sprintf("The UDM account '%s' has multi-factor authentication enabled. Set either the "+
+				"'totp_secret' attribute to the account's TOTP secret so codes are generated automatically or "+
+				"the 'mfa_token' attribute to a current one-time code.\n\t%s", username, apiErrorDetail(err)),
+		)
+	case err != nil:
 		resp.Diagnostics.AddError(
 			"UDM API: Authentication Failed",
-			fmt.Sprintf("Failed to authenticate to the UDM API:\n\t%s", err.Error()),
+			fmt.Sprintf("Failed to authenticate to the UDM API:\n\t%s", apiErrorDetail(err)),
 		)
 	}
 	if resp.Diagnostics.HasError() {
@@ -197,14 +649,24 @@ func (p *udmProvider) Configure(ctx context.Context, req provider.ConfigureReque
 func (p *udmProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewClientDeviceResource,
+		NewFirewallGroupResource,
+		NewFirewallPolicyResource,
+		NewFirewallRuleResource,
+		NewFirewallZoneResource,
+		NewNetworkResource,
+		NewPortForwardResource,
+		NewSiteResource,
 		NewStaticDNSRecordResource,
+		NewWLANResource,
 	}
 }
 
 func (p *udmProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewClientDevicesDataSource,
+		NewSitesDataSource,
 		NewStaticDNSRecordsDataSource,
+		NewSystemInfoDataSource,
 	}
 }
 
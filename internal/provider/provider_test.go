@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+// testAccProtoV6ProviderFactories are used to instantiate the provider during acceptance testing.  The factory
+// function is called for every Terraform CLI command executed to create a provider server to which the CLI can
+// reattach.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"udm": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// newTestAccServer starts a mock UDM for an acceptance test and returns it along with the provider configuration
+// needed to connect to it.  The server is shut down when the test finishes.
+func newTestAccServer(t *testing.T) (*udmmock.Server, string) {
+	t.Helper()
+	return newTestAccServerWithConfig(t, udmmock.Config{})
+}
+
+// newTestAccServerWithConfig is like newTestAccServer but starts the mock UDM with the given configuration.
+func newTestAccServerWithConfig(t *testing.T, serverConfig udmmock.Config) (*udmmock.Server, string) {
+	t.Helper()
+	server := udmmock.NewServer(serverConfig)
+	t.Cleanup(server.Close)
+
+	fingerprint := sha256.Sum256(server.Certificate().Raw)
+	config := fmt.Sprintf(`
+provider "udm" {
+  hostname               = %q
+  username               = %q
+  password               = %q
+  tls_fingerprint_sha256 = %q
+}
+`, server.Hostname(), udmmock.DefaultUsername, udmmock.DefaultPassword, api.FormatFingerprint(fingerprint[:]))
+	return server, config
+}
+
+// testAccCheckCollectionEmpty returns a check which verifies that a collection of a site on the mock UDM holds no
+// objects, ie: that every object created by the test in the site was destroyed.
+func testAccCheckCollectionEmpty(server *udmmock.Server, site, collection string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if objects := server.Objects(site, collection); len(objects) != 0 {
+			return fmt.Errorf("%d objects remain in the %s collection: %v", len(objects), collection, objects)
+		}
+		return nil
+	}
+}
+
+// testAccCheckObjectAttr returns a check which verifies the value of a field of an object in a site on the mock UDM,
+// looking up the object using the ID of the given resource.
+func testAccCheckObjectAttr(server *udmmock.Server, site, collection, resourceName, field string,
+	value any) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		object, ok := server.Object(site, collection, rs.Primary.ID)
+		if !ok {
+			return fmt.Errorf("object %s not found in the %s collection", rs.Primary.ID, collection)
+		}
+		if object[field] != value {
+			return fmt.Errorf("%s = %v, want %v", field, object[field], value)
+		}
+		return nil
+	}
+}
+
+// testAccDeleteAllObjects deletes every object in a collection of a site on the mock UDM, as if they were deleted in
+// the UniFi UI.
+func testAccDeleteAllObjects(server *udmmock.Server, site, collection string) {
+	for _, object := range server.Objects(site, collection) {
+		server.DeleteObject(site, collection, object["_id"].(string))
+	}
+}
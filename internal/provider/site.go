@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
+)
+
+// objectIDPattern matches the IDs assigned to objects by the UDM.
+var objectIDPattern = regexp.MustCompile(`^[0-9a-f]{24}$`)
+
+// siteFromModel returns the site a resource or data source is managed in, which is the site set in its configuration
+// or the provider's default site if none is set.
+func siteFromModel(site types.String, client *api.Client) string {
+	if site.IsNull() || site.IsUnknown() || site.ValueString() == "" {
+		return client.DefaultSite()
+	}
+	return site.ValueString()
+}
+
+// modifySitePlan sets the planned site of a resource to the site set in its configuration or the provider's default
+// site, and requires the resource to be replaced if it moves to another site.
+func modifySitePlan(ctx context.Context, client *api.Client, req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse) {
+
+	// nothing to do when the resource is destroyed or the provider has not been configured yet
+	if req.Plan.Raw.IsNull() || client == nil {
+		return
+	}
+
+	var configSite types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("site"), &configSite)...)
+	if resp.Diagnostics.HasError() || configSite.IsUnknown() {
+		return
+	}
+	site := siteFromModel(configSite, client)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("site"), site)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var stateSite types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("site"), &stateSite)...)
+	if !stateSite.IsNull() && stateSite.ValueString() != site {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("site"))
+	}
+}
+
+// importStateWithSite imports a resource by its ID, which may be prefixed with the name of the site the resource is
+// managed in (ie: "site/id").  The provider's default site is used if no site is given.
+func importStateWithSite(ctx context.Context, client *api.Client, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	site, id, ok := parseImportID(client, req, resp)
+	if !ok {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), site)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// parseImportID splits an import ID of the form "id" or "site/id" into the site and the ID, using the provider's
+// default site if no site is given.  An error diagnostic is added if the ID is malformed.
+func parseImportID(client *api.Client, req resource.ImportStateRequest, resp *resource.ImportStateResponse) (
+	string, string, bool) {
+
+	site, id := client.DefaultSite(), req.ID
+	if before, after, found := strings.Cut(req.ID, "/"); found {
+		site, id = before, after
+	}
+	if site == "" || id == "" || strings.Contains(id, "/") {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form 'id' or 'site/id', got: %s", req.ID),
+		)
+		return "", "", false
+	}
+	return site, id, true
+}
+
+// isObjectID returns whether or not a string looks like an ID assigned by the UDM rather than a name, as IDs are
+// always 24 hexadecimal digits.
+func isObjectID(s string) bool {
+	return objectIDPattern.MatchString(s)
+}
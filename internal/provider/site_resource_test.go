@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestAccSiteResource(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSiteCount(server, 1),
+		Steps: []resource.TestStep{
+			// create and read, with a record managed in the new site
+			{
+				Config: providerConfig + `
+resource "udm_site" "test" {
+  description = "Branch Office"
+}
+
+resource "udm_static_dns_record" "test" {
+  site        = udm_site.test.name
+  key         = "nas.branch.example.com"
+  record_type = "A"
+  value       = "10.20.0.10"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("udm_site.test", "id"),
+					resource.TestCheckResourceAttrSet("udm_site.test", "name"),
+					resource.TestCheckResourceAttr("udm_site.test", "description", "Branch Office"),
+					resource.TestCheckResourceAttrPair("udm_static_dns_record.test", "site", "udm_site.test", "name"),
+					testAccCheckSiteCount(server, 2),
+				),
+			},
+			// import by ID
+			{
+				ResourceName:      "udm_site.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// import by name
+			{
+				ResourceName:      "udm_site.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["udm_site.test"].Primary.Attributes["name"], nil
+				},
+			},
+			// rename
+			{
+				Config: providerConfig + `
+resource "udm_site" "test" {
+  description = "Branch Office (Berlin)"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_site.test", "description", "Branch Office (Berlin)"),
+					testAccCheckSiteDescription(server, "udm_site.test", "Branch Office (Berlin)"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSiteCount returns a check which verifies the number of sites on the mock UDM.
+func testAccCheckSiteCount(server *udmmock.Server, count int) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if sites := server.Sites(); len(sites) != count {
+			return fmt.Errorf("%d sites exist, want %d: %v", len(sites), count, sites)
+		}
+		return nil
+	}
+}
+
+// testAccCheckSiteDescription returns a check which verifies the description of the site on the mock UDM managed by
+// the given resource.
+func testAccCheckSiteDescription(server *udmmock.Server, resourceName, description string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		for _, site := range server.Sites() {
+			if site.ID != rs.Primary.ID {
+				continue
+			}
+			if site.Description != description {
+				return fmt.Errorf("description = %q, want %q", site.Description, description)
+			}
+			return nil
+		}
+		return fmt.Errorf("site %s not found", rs.Primary.ID)
+	}
+}
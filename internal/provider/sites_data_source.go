@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &sitesDataSource{}
+	_ datasource.DataSourceWithConfigure = &sitesDataSource{}
+)
+
+func NewSitesDataSource() datasource.DataSource {
+	return &sitesDataSource{}
+}
+
+type sitesDataSource struct {
+	client *api.Client
+}
+
+type sitesDataSourceModel struct {
+	Sites []siteDataSourceModel `tfsdk:"sites"`
+}
+
+type siteDataSourceModel struct {
+	ClientCount types.Int64  `tfsdk:"client_count"`
+	Description types.String `tfsdk:"description"`
+	DeviceCount types.Int64  `tfsdk:"device_count"`
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+}
+
+func (d *sitesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *sitesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_sites"
+}
+
+func (d *sitesDataSource) Schema(_ context.Context, req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse) {
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"sites": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"client_count": schema.Int64Attribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"device_count": schema.Int64Attribute{
+							Computed: true,
+						},
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *sitesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
+	resp *datasource.ReadResponse) {
+
+	// query for all sites
+	sites, err := d.client.GetSites(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve Sites",
+			fmt.Sprintf("Failed to retrieve sites from the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	state := sitesDataSourceModel{
+		Sites: []siteDataSourceModel{},
+	}
+	for _, site := range sites {
+		state.Sites = append(state.Sites, siteDataSourceModel{
+			ClientCount: types.Int64Value(int64(site.ClientCount())),
+			Description: types.StringValue(site.Description),
+			DeviceCount: types.Int64Value(int64(site.DeviceCount())),
+			ID:          types.StringValue(site.ID),
+			Name:        types.StringValue(site.Name),
+		})
+	}
+
+	// set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
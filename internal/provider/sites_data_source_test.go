@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestAccSitesDataSource(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	server.AddSite("branch", "Branch Office")
+	server.AddObject("branch", udmmock.CollectionDevices, map[string]any{"mac": "00:00:00:00:00:01"})
+	server.AddObject("branch", udmmock.CollectionClientDevices, map[string]any{"mac": "00:00:00:00:00:02"})
+	server.AddObject("branch", udmmock.CollectionClientDevices, map[string]any{"mac": "00:00:00:00:00:03"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "udm_sites" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.udm_sites.test", "sites.#", "2"),
+					resource.TestCheckResourceAttr("data.udm_sites.test", "sites.0.name", "branch"),
+					resource.TestCheckResourceAttr("data.udm_sites.test", "sites.0.description", "Branch Office"),
+					resource.TestCheckResourceAttrSet("data.udm_sites.test", "sites.0.id"),
+					resource.TestCheckResourceAttr("data.udm_sites.test", "sites.0.device_count", "1"),
+					resource.TestCheckResourceAttr("data.udm_sites.test", "sites.0.client_count", "2"),
+					resource.TestCheckResourceAttr("data.udm_sites.test", "sites.1.name", udmmock.DefaultSite),
+					resource.TestCheckResourceAttr("data.udm_sites.test", "sites.1.device_count", "0"),
+				),
+			},
+		},
+	})
+}
@@ -2,14 +2,15 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
 )
@@ -19,6 +20,7 @@ var (
 	_ resource.Resource                = &staticDNSRecordResource{}
 	_ resource.ResourceWithConfigure   = &staticDNSRecordResource{}
 	_ resource.ResourceWithImportState = &staticDNSRecordResource{}
+	_ resource.ResourceWithModifyPlan  = &staticDNSRecordResource{}
 )
 
 // NewStaticDNSRecordResource is a helper function to simplify the provider implementation.
@@ -39,6 +41,7 @@ type staticDNSRecordResourceModel struct {
 	Priority   types.Int32  `tfsdk:"priority"`
 	RecordType types.String `tfsdk:"record_type"`
 	TTL        types.Int32  `tfsdk:"ttl"`
+	Site       types.String `tfsdk:"site"`
 	Value      types.String `tfsdk:"value"`
 	Weight     types.Int32  `tfsdk:"weight"`
 }
@@ -95,6 +98,10 @@ func (r *staticDNSRecordResource) Schema(_ context.Context, _ resource.SchemaReq
 			"record_type": schema.StringAttribute{
 				Required: true,
 			},
+			"site": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+			},
 			"ttl": schema.Int32Attribute{
 				Computed: true,
 				Optional: true,
@@ -143,11 +150,12 @@ func (r *staticDNSRecordResource) Create(ctx context.Context, req resource.Creat
 	}
 
 	// create the record
-	createdRecord, err := r.client.CreateStaticDNSRecord(ctx, record)
+	site := siteFromModel(plan.Site, r.client)
+	createdRecord, err := r.client.CreateStaticDNSRecord(ctx, site, record)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"UDM API: Failed to Create Static DNS Record",
-			fmt.Sprintf("Failed to create static DNS record using the UDM API:\n\t%s", err.Error()),
+			fmt.Sprintf("Failed to create static DNS record using the UDM API:\n\t%s", apiErrorDetail(err)),
 		)
 		return
 	}
@@ -159,6 +167,7 @@ func (r *staticDNSRecordResource) Create(ctx context.Context, req resource.Creat
 	plan.Port = types.Int32Value(int32(createdRecord.Port))
 	plan.Priority = types.Int32Value(int32(createdRecord.Priority))
 	plan.RecordType = types.StringValue(createdRecord.RecordType)
+	plan.Site = types.StringValue(site)
 	plan.TTL = types.Int32Value(int32(createdRecord.TTL))
 	plan.Value = types.StringValue(createdRecord.Value)
 	plan.Weight = types.Int32Value(int32(createdRecord.Weight))
@@ -181,13 +190,23 @@ func (r *staticDNSRecordResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	// refresh value from the API
-	record, err := r.client.GetStaticDNSRecord(ctx, state.ID.ValueString())
+	// refresh value from the API - resources created before sites could be chosen live in the default site
+	site := siteFromModel(state.Site, r.client)
+	record, err := r.client.GetStaticDNSRecord(ctx, site, state.ID.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		// the static DNS record was removed outside of Terraform so drop it from the state to have it recreated
+		tflog.Warn(ctx, "static DNS record no longer exists - removing from state", map[string]any{
+			"id":   state.ID.ValueString(),
+			"site": site,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"UDM API: Failed to Retrieve Static DNS Record",
 			fmt.Sprintf("Failed to retrieve the static DNS record with the ID '%s': %s",
-				state.ID.ValueString(), err.Error()),
+				state.ID.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -198,6 +217,7 @@ func (r *staticDNSRecordResource) Read(ctx context.Context, req resource.ReadReq
 	state.Port = types.Int32Value(int32(record.Port))
 	state.Priority = types.Int32Value(int32(record.Priority))
 	state.RecordType = types.StringValue(record.RecordType)
+	state.Site = types.StringValue(site)
 	state.TTL = types.Int32Value(int32(record.TTL))
 	state.Value = types.StringValue(record.Value)
 	state.Weight = types.Int32Value(int32(record.Weight))
@@ -242,12 +262,13 @@ func (r *staticDNSRecordResource) Update(ctx context.Context, req resource.Updat
 		record.Weight = int(plan.Weight.ValueInt32())
 	}
 
-	// create the record
-	updatedRecord, err := r.client.UpdateStaticDNSRecord(ctx, plan.ID.ValueString(), record)
+	// update the record
+	site := siteFromModel(plan.Site, r.client)
+	updatedRecord, err := r.client.UpdateStaticDNSRecord(ctx, site, plan.ID.ValueString(), record)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"UDM API: Failed to Update Static DNS Record",
-			fmt.Sprintf("Failed to update static DNS record using the UDM API:\n\t%s", err.Error()),
+			fmt.Sprintf("Failed to update static DNS record using the UDM API:\n\t%s", apiErrorDetail(err)),
 		)
 		return
 	}
@@ -259,6 +280,7 @@ func (r *staticDNSRecordResource) Update(ctx context.Context, req resource.Updat
 	plan.Port = types.Int32Value(int32(updatedRecord.Port))
 	plan.Priority = types.Int32Value(int32(updatedRecord.Priority))
 	plan.RecordType = types.StringValue(updatedRecord.RecordType)
+	plan.Site = types.StringValue(site)
 	plan.TTL = types.Int32Value(int32(updatedRecord.TTL))
 	plan.Value = types.StringValue(updatedRecord.Value)
 	plan.Weight = types.Int32Value(int32(updatedRecord.Weight))
@@ -282,15 +304,27 @@ func (r *staticDNSRecordResource) Delete(ctx context.Context, req resource.Delet
 	}
 
 	// delete the record
-	if err := r.client.DeleteStaticDNSRecord(ctx, state.ID.ValueString()); err != nil {
+	if err := r.client.DeleteStaticDNSRecord(ctx, siteFromModel(state.Site, r.client),
+		state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
-			"UDM API: Failed to Update Static DNS Record",
-			fmt.Sprintf("Failed to update static DNS record using the UDM API:\n\t%s", err.Error()),
+			"UDM API: Failed to Delete Static DNS Record",
+			fmt.Sprintf("Failed to delete static DNS record using the UDM API:\n\t%s", apiErrorDetail(err)),
 		)
 		return
 	}
 }
 
+// ModifyPlan checks that the UDM supports static DNS records, sets the planned site of the record and replaces the
+// record when it is moved to another site.
+func (r *staticDNSRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// records can always be destroyed
+	if !req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(requireFeature(r.client, api.FeatureStaticDNSRecords)...)
+	}
+	modifySitePlan(ctx, r.client, req, resp)
+}
+
+// ImportState imports a record by its ID or by its site and ID (ie: "site/id").
 func (r *staticDNSRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithSite(ctx, r.client, req, resp)
 }
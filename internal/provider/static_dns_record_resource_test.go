@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestAccStaticDNSRecordResource(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckCollectionEmpty(server, udmmock.DefaultSite,
+			udmmock.CollectionStaticDNSRecords),
+		Steps: []resource.TestStep{
+			// create and read
+			{
+				Config: providerConfig + `
+resource "udm_static_dns_record" "test" {
+  enabled     = true
+  key         = "nas.example.com"
+  record_type = "A"
+  ttl         = 300
+  value       = "192.168.1.10"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("udm_static_dns_record.test", "id"),
+					resource.TestCheckResourceAttr("udm_static_dns_record.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("udm_static_dns_record.test", "key", "nas.example.com"),
+					resource.TestCheckResourceAttr("udm_static_dns_record.test", "record_type", "A"),
+					resource.TestCheckResourceAttr("udm_static_dns_record.test", "ttl", "300"),
+					resource.TestCheckResourceAttr("udm_static_dns_record.test", "value", "192.168.1.10"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionStaticDNSRecords,
+						"udm_static_dns_record.test", "value", "192.168.1.10"),
+				),
+			},
+			// import
+			{
+				ResourceName:      "udm_static_dns_record.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// update and read
+			{
+				Config: providerConfig + `
+resource "udm_static_dns_record" "test" {
+  enabled     = false
+  key         = "nas.example.com"
+  record_type = "A"
+  ttl         = 600
+  value       = "192.168.1.11"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_static_dns_record.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("udm_static_dns_record.test", "ttl", "600"),
+					resource.TestCheckResourceAttr("udm_static_dns_record.test", "value", "192.168.1.11"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionStaticDNSRecords,
+						"udm_static_dns_record.test", "value", "192.168.1.11"),
+				),
+			},
+			// recreate after the record was deleted outside of Terraform
+			{
+				PreConfig: func() {
+					testAccDeleteAllObjects(server, udmmock.DefaultSite, udmmock.CollectionStaticDNSRecords)
+				},
+				Config: providerConfig + `
+resource "udm_static_dns_record" "test" {
+  enabled     = false
+  key         = "nas.example.com"
+  record_type = "A"
+  ttl         = 600
+  value       = "192.168.1.11"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionStaticDNSRecords,
+						"udm_static_dns_record.test", "key", "nas.example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStaticDNSRecordResourceSite(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	server.AddSite("branch", "Branch Office")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckCollectionEmpty(server, udmmock.DefaultSite, udmmock.CollectionStaticDNSRecords),
+			testAccCheckCollectionEmpty(server, "branch", udmmock.CollectionStaticDNSRecords),
+		),
+		Steps: []resource.TestStep{
+			// create in another site
+			{
+				Config: providerConfig + `
+resource "udm_static_dns_record" "test" {
+  site        = "branch"
+  key         = "nas.branch.example.com"
+  record_type = "A"
+  value       = "10.20.0.10"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_static_dns_record.test", "site", "branch"),
+					testAccCheckObjectAttr(server, "branch", udmmock.CollectionStaticDNSRecords,
+						"udm_static_dns_record.test", "value", "10.20.0.10"),
+					testAccCheckCollectionEmpty(server, udmmock.DefaultSite, udmmock.CollectionStaticDNSRecords),
+				),
+			},
+			// import with the site in the ID
+			{
+				ResourceName:      "udm_static_dns_record.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["udm_static_dns_record.test"]
+					return fmt.Sprintf("branch/%s", rs.Primary.ID), nil
+				},
+			},
+			// moving the record to the default site replaces it
+			{
+				Config: providerConfig + `
+resource "udm_static_dns_record" "test" {
+  key         = "nas.branch.example.com"
+  record_type = "A"
+  value       = "10.20.0.10"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_static_dns_record.test", "site", udmmock.DefaultSite),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionStaticDNSRecords,
+						"udm_static_dns_record.test", "value", "10.20.0.10"),
+					testAccCheckCollectionEmpty(server, "branch", udmmock.CollectionStaticDNSRecords),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStaticDNSRecordResourceUnsupportedVersion(t *testing.T) {
+	server, providerConfig := newTestAccServerWithConfig(t, udmmock.Config{NetworkAppVersion: "8.1.127"})
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "udm_static_dns_record" "test" {
+  key         = "nas.example.com"
+  record_type = "A"
+  value       = "192.168.1.10"
+}
+`,
+				ExpectError: regexp.MustCompile(`require Network application >= 8\.2\.0 but the UDM is\s+running 8\.1\.127`),
+			},
+			{
+				Config: providerConfig + `
+data "udm_static_dns_records" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`require Network application >= 8\.2\.0`),
+			},
+		},
+	})
+	if n := server.RequestCount("POST", "/proxy/network/v2/api/site/default/static-dns"); n != 0 {
+		t.Errorf("%d static DNS records were created, want 0", n)
+	}
+}
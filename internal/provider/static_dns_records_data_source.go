@@ -28,6 +28,7 @@ type staticDNSRecordsDataSource struct {
 type staticDNSRecordsDataSourceModel struct {
 	Records []staticDNSRecordDataSourceModel `tfsdk:"records"`
 	Filter  *staticDNSFilterDataSourceModel  `tfsdk:"filter"`
+	Site    types.String                     `tfsdk:"site"`
 }
 
 type staticDNSFilterDataSourceModel struct {
@@ -117,6 +118,10 @@ func (d *staticDNSRecordsDataSource) Schema(_ context.Context, req datasource.Sc
 					},
 				},
 			},
+			"site": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+			},
 			"filter": schema.SingleNestedAttribute{
 				Optional: true,
 				Attributes: map[string]schema.Attribute{
@@ -152,12 +157,19 @@ func (d *staticDNSRecordsDataSource) Read(ctx context.Context, req datasource.Re
 		return
 	}
 
+	// static DNS records are only available in newer versions of the network application
+	resp.Diagnostics.Append(requireFeature(d.client, api.FeatureStaticDNSRecords)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// query for all records
-	records, err := d.client.GetStaticDNSRecords(ctx)
+	site := siteFromModel(config.Site, d.client)
+	records, err := d.client.GetStaticDNSRecords(ctx, site)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"UDM API: Failed to Retrieve Static DNS Records",
-			fmt.Sprintf("Failed to retrieve static DNS records from the UDM API:\n\t%s", err.Error()),
+			fmt.Sprintf("Failed to retrieve static DNS records from the UDM API:\n\t%s", apiErrorDetail(err)),
 		)
 		return
 	}
@@ -166,6 +178,7 @@ func (d *staticDNSRecordsDataSource) Read(ctx context.Context, req datasource.Re
 	state := staticDNSRecordsDataSourceModel{
 		Records: []staticDNSRecordDataSourceModel{},
 		Filter:  config.Filter,
+		Site:    types.StringValue(site),
 	}
 	for _, record := range records {
 		if config.Filter != nil {
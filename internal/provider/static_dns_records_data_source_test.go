@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestAccStaticDNSRecordsDataSource(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	for _, record := range []map[string]any{
+		{"enabled": true, "key": "nas.example.com", "record_type": "A", "ttl": 300, "value": "192.168.1.10"},
+		{"enabled": true, "key": "www.example.com", "record_type": "CNAME", "ttl": 300, "value": "nas.example.com"},
+		{"enabled": false, "key": "old.example.com", "record_type": "A", "ttl": 300, "value": "192.168.1.20"},
+	} {
+		server.AddObject(udmmock.DefaultSite, udmmock.CollectionStaticDNSRecords, record)
+	}
+	server.AddSite("branch", "Branch Office")
+	server.AddObject("branch", udmmock.CollectionStaticDNSRecords, map[string]any{
+		"enabled": true, "key": "nas.branch.example.com", "record_type": "A", "ttl": 300, "value": "10.20.0.10",
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// no filter
+			{
+				Config: providerConfig + `
+data "udm_static_dns_records" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.udm_static_dns_records.test", "records.#", "3"),
+					resource.TestCheckResourceAttr("data.udm_static_dns_records.test", "site", udmmock.DefaultSite),
+				),
+			},
+			// filter by record type
+			{
+				Config: providerConfig + `
+data "udm_static_dns_records" "test" {
+  filter = {
+    record_type = "A"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.udm_static_dns_records.test", "records.#", "2"),
+					resource.TestCheckResourceAttr("data.udm_static_dns_records.test", "records.0.key",
+						"nas.example.com"),
+					resource.TestCheckResourceAttr("data.udm_static_dns_records.test", "records.1.key",
+						"old.example.com"),
+				),
+			},
+			// filter by record type and enabled status
+			{
+				Config: providerConfig + `
+data "udm_static_dns_records" "test" {
+  filter = {
+    enabled     = true
+    record_type = "A"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.udm_static_dns_records.test", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.udm_static_dns_records.test", "records.0.value",
+						"192.168.1.10"),
+				),
+			},
+			// another site
+			{
+				Config: providerConfig + `
+data "udm_static_dns_records" "test" {
+  site = "branch"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.udm_static_dns_records.test", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.udm_static_dns_records.test", "records.0.key",
+						"nas.branch.example.com"),
+					resource.TestCheckResourceAttr("data.udm_static_dns_records.test", "site", "branch"),
+				),
+			},
+			// no matches
+			{
+				Config: providerConfig + `
+data "udm_static_dns_records" "test" {
+  filter = {
+    key = "missing.example.com"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.udm_static_dns_records.test", "records.#", "0"),
+				),
+			},
+		},
+	})
+}
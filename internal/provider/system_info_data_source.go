@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &systemInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &systemInfoDataSource{}
+)
+
+func NewSystemInfoDataSource() datasource.DataSource {
+	return &systemInfoDataSource{}
+}
+
+type systemInfoDataSource struct {
+	client *api.Client
+}
+
+type systemInfoDataSourceModel struct {
+	ConsoleType       types.String `tfsdk:"console_type"`
+	DeviceID          types.String `tfsdk:"device_id"`
+	Hostname          types.String `tfsdk:"hostname"`
+	Model             types.String `tfsdk:"model"`
+	ModelAbbreviation types.String `tfsdk:"model_abbreviation"`
+	ModelFullName     types.String `tfsdk:"model_full_name"`
+	Name              types.String `tfsdk:"name"`
+	NetworkAppVersion types.String `tfsdk:"network_app_version"`
+	SKU               types.String `tfsdk:"sku"`
+	UnifiOSVersion    types.String `tfsdk:"unifi_os_version"`
+	Uptime            types.Int64  `tfsdk:"uptime"`
+}
+
+func (d *systemInfoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *systemInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_system_info"
+}
+
+func (d *systemInfoDataSource) Schema(_ context.Context, req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse) {
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"console_type": schema.StringAttribute{
+				Computed: true,
+			},
+			"device_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"hostname": schema.StringAttribute{
+				Computed: true,
+			},
+			"model": schema.StringAttribute{
+				Computed: true,
+			},
+			"model_abbreviation": schema.StringAttribute{
+				Computed: true,
+			},
+			"model_full_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Computed: true,
+			},
+			"network_app_version": schema.StringAttribute{
+				Computed: true,
+			},
+			"sku": schema.StringAttribute{
+				Computed: true,
+			},
+			"unifi_os_version": schema.StringAttribute{
+				Computed: true,
+			},
+			"uptime": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *systemInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest,
+	resp *datasource.ReadResponse) {
+
+	// query for the current info rather than the info collected at login so the uptime is accurate
+	info, err := d.client.GetNetworkAppInfo(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve System Info",
+			fmt.Sprintf("Failed to retrieve system info from the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	system := info.System
+	state := systemInfoDataSourceModel{
+		ConsoleType:       types.StringValue(system.UnifiConsole.Type),
+		DeviceID:          types.StringValue(system.DeviceID),
+		Hostname:          types.StringValue(system.Hostname),
+		Model:             types.StringValue(system.HostMetadata.ModelName),
+		ModelAbbreviation: types.StringValue(system.HostMetadata.ModelAbbreviation),
+		ModelFullName:     types.StringValue(system.HostMetadata.ModelFullName),
+		Name:              types.StringValue(system.Name),
+		NetworkAppVersion: types.StringValue(system.Version),
+		SKU:               types.StringValue(system.HostMetadata.SKU),
+		UnifiOSVersion:    types.StringValue(system.UnifiConsole.Version),
+		Uptime:            types.Int64Value(int64(system.Uptime)),
+	}
+
+	// set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+func TestAccSystemInfoDataSource(t *testing.T) {
+	_, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "udm_system_info" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.udm_system_info.test", "console_type", "UDM"),
+					resource.TestCheckResourceAttrSet("data.udm_system_info.test", "device_id"),
+					resource.TestCheckResourceAttr("data.udm_system_info.test", "hostname", "udm-mock"),
+					resource.TestCheckResourceAttr("data.udm_system_info.test", "model", "UniFi Dream Machine"),
+					resource.TestCheckResourceAttr("data.udm_system_info.test", "model_abbreviation", "UDM"),
+					resource.TestCheckResourceAttr("data.udm_system_info.test", "sku", "UDM-MOCK"),
+					resource.TestCheckResourceAttr("data.udm_system_info.test", "network_app_version",
+						udmmock.DefaultNetworkAppVersion),
+					resource.TestMatchResourceAttr("data.udm_system_info.test", "unifi_os_version",
+						regexp.MustCompile(`^\d+\.\d+\.\d+$`)),
+					resource.TestMatchResourceAttr("data.udm_system_info.test", "uptime", regexp.MustCompile(`^\d+$`)),
+				),
+			},
+		},
+	})
+}
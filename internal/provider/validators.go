@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure the implementations satisfy the expected interfaces.
+var (
+	_ validator.String = cidrValidator{}
+	_ validator.String = ipAddressValidator{}
+	_ validator.String = macAddressValidator{}
+	_ validator.String = portsValidator{}
+)
+
+// macAddressPattern matches a MAC address in the form used by the UDM.
+var macAddressPattern = regexp.MustCompile(`^([0-9a-f]{2}:){5}[0-9a-f]{2}$`)
+
+// cidrValidator validates that a string is an IPv4 or IPv6 subnet in CIDR notation (eg: 192.168.1.0/24) with no host bits
+// set.
+type cidrValidator struct{}
+
+func (v cidrValidator) Description(_ context.Context) string {
+	return "value must be a subnet in CIDR notation"
+}
+
+func (v cidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	prefix, err := netip.ParsePrefix(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Subnet",
+			fmt.Sprintf("Expected a subnet in CIDR notation (eg: 192.168.1.0/24), got: %s", req.ConfigValue.ValueString()),
+		)
+		return
+	}
+
+	// the UDM only stores the subnet itself so any host bits would be lost
+	if prefix != prefix.Masked() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Subnet",
+			fmt.Sprintf("Expected a subnet without any host bits set (eg: %s), got: %s", prefix.Masked(),
+				req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// ipAddressValidator validates that a string is an IPv4 or IPv6 address.
+type ipAddressValidator struct{}
+
+func (v ipAddressValidator) Description(_ context.Context) string {
+	return "value must be an IP address"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := netip.ParseAddr(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IP Address",
+			fmt.Sprintf("Expected an IPv4 or IPv6 address, got: %s", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// macAddressValidator validates that a string is a MAC address in the lowercase, colon-separated form returned by the UDM
+// (eg: 00:11:22:aa:bb:cc).
+type macAddressValidator struct{}
+
+func (v macAddressValidator) Description(_ context.Context) string {
+	return "value must be a lowercase MAC address separated by colons"
+}
+
+func (v macAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v macAddressValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if !macAddressPattern.MatchString(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid MAC Address",
+			fmt.Sprintf("Expected a lowercase MAC address (eg: 00:11:22:aa:bb:cc), got: %s", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// portsValidator validates that a string is a port, a range of ports or a comma-separated list of both
+// (eg: 80,443,8000-8080).
+type portsValidator struct{}
+
+func (v portsValidator) Description(_ context.Context) string {
+	return "value must be a port, a range of ports or a comma-separated list of ports and ranges"
+}
+
+func (v portsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v portsValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if err := validatePorts(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Ports",
+			fmt.Sprintf("Expected a port, a range of ports or a comma-separated list of both (eg: 80,443,8000-8080): %s",
+				err),
+		)
+	}
+}
+
+// validatePorts returns an error if a string is not a port, a range of ports or a comma-separated list of both.
+func validatePorts(s string) error {
+	for _, item := range strings.Split(s, ",") {
+		if err := validatePortRange(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePortRange returns an error if a string is not a port or a range of ports (eg: 8000-8080).
+func validatePortRange(s string) error {
+	first, last, isRange := strings.Cut(s, "-")
+	start, err := parsePort(first)
+	if err != nil || !isRange {
+		return err
+	}
+	end, err := parsePort(last)
+	if err != nil {
+		return err
+	}
+	if start >= end {
+		return fmt.Errorf("the range %s does not end after it starts", s)
+	}
+	return nil
+}
+
+// parsePort parses a port number between 1 and 65535.
+func parsePort(s string) (int, error) {
+	port, err := strconv.Atoi(s)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("'%s' is not a port between 1 and 65535", s)
+	}
+	return port, nil
+}
+
+// parseAddressOrPrefix parses an IP address or a subnet in CIDR notation, returning an address as a prefix containing
+// only that address.
+func parseAddressOrPrefix(s string) (netip.Prefix, error) {
+	if prefix, err := netip.ParsePrefix(s); err == nil {
+		return prefix, nil
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("'%s' is not an IP address or a subnet in CIDR notation", s)
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// validateAddressRange returns an error if a string is not an IP address, a subnet in CIDR notation or a range of IP
+// addresses (eg: 192.168.1.10-192.168.1.20) of the given address family.
+func validateAddressRange(s string, ipv6 bool) error {
+	family := "IPv4"
+	if ipv6 {
+		family = "IPv6"
+	}
+	if first, last, isRange := strings.Cut(s, "-"); isRange {
+		start, err := netip.ParseAddr(first)
+		if err != nil || start.Is6() != ipv6 {
+			return fmt.Errorf("'%s' is not an %s address", first, family)
+		}
+		end, err := netip.ParseAddr(last)
+		if err != nil || end.Is6() != ipv6 {
+			return fmt.Errorf("'%s' is not an %s address", last, family)
+		}
+		if !start.Less(end) {
+			return fmt.Errorf("the range %s does not end after it starts", s)
+		}
+		return nil
+	}
+	prefix, err := parseAddressOrPrefix(s)
+	if err != nil {
+		return err
+	}
+	if prefix.Addr().Is6() != ipv6 {
+		return fmt.Errorf("'%s' is not an %s address or subnet", s, family)
+	}
+	return nil
+}
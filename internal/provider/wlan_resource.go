@@ -0,0 +1,641 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &wlanResource{}
+	_ resource.ResourceWithConfigure      = &wlanResource{}
+	_ resource.ResourceWithImportState    = &wlanResource{}
+	_ resource.ResourceWithModifyPlan     = &wlanResource{}
+	_ resource.ResourceWithValidateConfig = &wlanResource{}
+)
+
+// wlanSecurityModes maps the security modes of the resource to the security, WPA3 support and WPA3 transition settings
+// of a WLAN.
+var wlanSecurityModes = map[string]struct {
+	security       string
+	wpa3Support    bool
+	wpa3Transition bool
+}{
+	"open":            {security: "open"},
+	"wpa2":            {security: "wpapsk"},
+	"wpa3":            {security: "wpapsk", wpa3Support: true},
+	"wpa2-wpa3":       {security: "wpapsk", wpa3Support: true, wpa3Transition: true},
+	"wpa2-enterprise": {security: "wpaeap"},
+	"wpa3-enterprise": {security: "wpaeap", wpa3Support: true},
+}
+
+// wlanScheduleAttrTypes are the attribute types of an entry in the schedules of a WLAN.
+var wlanScheduleAttrTypes = map[string]attr.Type{
+	"days_of_week":     types.ListType{ElemType: types.StringType},
+	"duration_minutes": types.Int32Type,
+	"start_hour":       types.Int32Type,
+	"start_minute":     types.Int32Type,
+}
+
+// NewWLANResource is a helper function to simplify the provider implementation.
+func NewWLANResource() resource.Resource {
+	return &wlanResource{}
+}
+
+// wlanResource is the resource implementation.
+type wlanResource struct {
+	client *api.Client
+}
+
+type wlanResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	APGroupIDs            types.Set    `tfsdk:"ap_group_ids"`
+	BandSteering          types.Bool   `tfsdk:"band_steering"`
+	ClientIsolation       types.Bool   `tfsdk:"client_isolation"`
+	Enabled               types.Bool   `tfsdk:"enabled"`
+	HideSSID              types.Bool   `tfsdk:"hide_ssid"`
+	MACFilterEnabled      types.Bool   `tfsdk:"mac_filter_enabled"`
+	MACFilterList         types.Set    `tfsdk:"mac_filter_list"`
+	MACFilterPolicy       types.String `tfsdk:"mac_filter_policy"`
+	MinimumDataRate2GKbps types.Int32  `tfsdk:"minimum_data_rate_2g_kbps"`
+	MinimumDataRate5GKbps types.Int32  `tfsdk:"minimum_data_rate_5g_kbps"`
+	NetworkID             types.String `tfsdk:"network_id"`
+	Passphrase            types.String `tfsdk:"passphrase"`
+	RADIUSProfileID       types.String `tfsdk:"radius_profile_id"`
+	Schedules             types.List   `tfsdk:"schedules"`
+	Security              types.String `tfsdk:"security"`
+	Site                  types.String `tfsdk:"site"`
+	SSID                  types.String `tfsdk:"ssid"`
+}
+
+type wlanScheduleModel struct {
+	DaysOfWeek      []string    `tfsdk:"days_of_week"`
+	DurationMinutes types.Int32 `tfsdk:"duration_minutes"`
+	StartHour       types.Int32 `tfsdk:"start_hour"`
+	StartMinute     types.Int32 `tfsdk:"start_minute"`
+}
+
+// applyTo copies the values set in the model to a WLAN, leaving the fields of any unset values untouched.
+func (m wlanResourceModel) applyTo(ctx context.Context, wlan *api.WLAN) diag.Diagnostics {
+	var diags diag.Diagnostics
+	wlan.Name = m.SSID.ValueString()
+	wlan.NetworkConfID = m.NetworkID.ValueString()
+	mode := wlanSecurityModes[m.Security.ValueString()]
+	wlan.Security = mode.security
+	wlan.WPA3Support = mode.wpa3Support
+	wlan.WPA3Transition = mode.wpa3Transition
+	if mode.security != "open" {
+		wlan.WPAMode = "wpa2"
+		wlan.WPAEncryption = "ccmp"
+	}
+	wlan.Passphrase = m.Passphrase.ValueString()
+	wlan.RADIUSProfileID = m.RADIUSProfileID.ValueString()
+	wlan.MinRate2GEnabled = !m.MinimumDataRate2GKbps.IsNull()
+	if !m.MinimumDataRate2GKbps.IsNull() && !m.MinimumDataRate2GKbps.IsUnknown() {
+		wlan.MinRate2GDataRateKbps = int(m.MinimumDataRate2GKbps.ValueInt32())
+	}
+	wlan.MinRate5GEnabled = !m.MinimumDataRate5GKbps.IsNull()
+	if !m.MinimumDataRate5GKbps.IsNull() && !m.MinimumDataRate5GKbps.IsUnknown() {
+		wlan.MinRate5GDataRateKbps = int(m.MinimumDataRate5GKbps.ValueInt32())
+	}
+	if !m.APGroupIDs.IsNull() && !m.APGroupIDs.IsUnknown() {
+		wlan.APGroupIDs = []string{}
+		diags.Append(m.APGroupIDs.ElementsAs(ctx, &wlan.APGroupIDs, false)...)
+	}
+	if wlan.APGroupIDs == nil {
+		wlan.APGroupIDs = []string{}
+	}
+	wlan.APGroupMode = "all"
+	if len(wlan.APGroupIDs) > 0 {
+		wlan.APGroupMode = "groups"
+	}
+	if !m.BandSteering.IsNull() && !m.BandSteering.IsUnknown() {
+		wlan.BandSteering = m.BandSteering.ValueBool()
+	}
+	if !m.ClientIsolation.IsNull() && !m.ClientIsolation.IsUnknown() {
+		wlan.L2Isolation = m.ClientIsolation.ValueBool()
+	}
+	if !m.Enabled.IsNull() && !m.Enabled.IsUnknown() {
+		wlan.Enabled = m.Enabled.ValueBool()
+	}
+	if !m.HideSSID.IsNull() && !m.HideSSID.IsUnknown() {
+		wlan.HideSSID = m.HideSSID.ValueBool()
+	}
+	if !m.MACFilterEnabled.IsNull() && !m.MACFilterEnabled.IsUnknown() {
+		wlan.MACFilterEnabled = m.MACFilterEnabled.ValueBool()
+	}
+	if !m.MACFilterList.IsNull() && !m.MACFilterList.IsUnknown() {
+		wlan.MACFilterList = []string{}
+		diags.Append(m.MACFilterList.ElementsAs(ctx, &wlan.MACFilterList, false)...)
+	}
+	if wlan.MACFilterList == nil {
+		wlan.MACFilterList = []string{}
+	}
+	if !m.MACFilterPolicy.IsNull() && !m.MACFilterPolicy.IsUnknown() {
+		wlan.MACFilterPolicy = m.MACFilterPolicy.ValueString()
+	}
+	if !m.Schedules.IsNull() && !m.Schedules.IsUnknown() {
+		var schedules []wlanScheduleModel
+		diags.Append(m.Schedules.ElementsAs(ctx, &schedules, false)...)
+		wlan.Schedule = []api.WLANScheduleSlot{}
+		for _, schedule := range schedules {
+			wlan.Schedule = append(wlan.Schedule, api.WLANScheduleSlot{
+				DurationMinutes: int(schedule.DurationMinutes.ValueInt32()),
+				StartDaysOfWeek: schedule.DaysOfWeek,
+				StartHour:       int(schedule.StartHour.ValueInt32()),
+				StartMinute:     int(schedule.StartMinute.ValueInt32()),
+			})
+		}
+	}
+	if wlan.Schedule == nil {
+		wlan.Schedule = []api.WLANScheduleSlot{}
+	}
+	wlan.ScheduleEnabled = len(wlan.Schedule) > 0
+	return diags
+}
+
+// readFrom sets the model to the values of a WLAN.
+func (m *wlanResourceModel) readFrom(ctx context.Context, wlan api.WLAN) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	m.ID = types.StringValue(wlan.ID)
+	m.SSID = types.StringValue(wlan.Name)
+	m.NetworkID = types.StringValue(wlan.NetworkConfID)
+	m.Security = types.StringValue(wlanSecurityMode(wlan))
+	m.RADIUSProfileID = types.StringNull()
+	if wlan.Security == "wpaeap" && wlan.RADIUSProfileID != "" {
+		m.RADIUSProfileID = types.StringValue(wlan.RADIUSProfileID)
+	}
+
+	// the UDM returns the passphrase of WLANs using a pre-shared key, which allows rotated passphrases to be detected
+	switch {
+	case wlan.Security != "wpapsk":
+		m.Passphrase = types.StringNull()
+	case wlan.Passphrase != "":
+		m.Passphrase = types.StringValue(wlan.Passphrase)
+	}
+
+	m.MinimumDataRate2GKbps = types.Int32Null()
+	if wlan.MinRate2GEnabled {
+		m.MinimumDataRate2GKbps = types.Int32Value(int32(wlan.MinRate2GDataRateKbps))
+	}
+	m.MinimumDataRate5GKbps = types.Int32Null()
+	if wlan.MinRate5GEnabled {
+		m.MinimumDataRate5GKbps = types.Int32Value(int32(wlan.MinRate5GDataRateKbps))
+	}
+	m.APGroupIDs, d = types.SetValueFrom(ctx, types.StringType, nonNilStrings(wlan.APGroupIDs))
+	diags.Append(d...)
+	m.BandSteering = types.BoolValue(wlan.BandSteering)
+	m.ClientIsolation = types.BoolValue(wlan.L2Isolation)
+	m.Enabled = types.BoolValue(wlan.Enabled)
+	m.HideSSID = types.BoolValue(wlan.HideSSID)
+	m.MACFilterEnabled = types.BoolValue(wlan.MACFilterEnabled)
+	m.MACFilterList, d = types.SetValueFrom(ctx, types.StringType, nonNilStrings(wlan.MACFilterList))
+	diags.Append(d...)
+	m.MACFilterPolicy = types.StringValue(wlan.MACFilterPolicy)
+
+	schedules := []wlanScheduleModel{}
+	if wlan.ScheduleEnabled {
+		for _, slot := range wlan.Schedule {
+			schedules = append(schedules, wlanScheduleModel{
+				DaysOfWeek:      nonNilStrings(slot.StartDaysOfWeek),
+				DurationMinutes: types.Int32Value(int32(slot.DurationMinutes)),
+				StartHour:       types.Int32Value(int32(slot.StartHour)),
+				StartMinute:     types.Int32Value(int32(slot.StartMinute)),
+			})
+		}
+	}
+	m.Schedules, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: wlanScheduleAttrTypes}, schedules)
+	diags.Append(d...)
+	return diags
+}
+
+// wlanSecurityMode returns the security mode of the resource matching the settings of a WLAN.
+func wlanSecurityMode(wlan api.WLAN) string {
+	switch {
+	case wlan.Security == "wpapsk" && wlan.WPA3Support && wlan.WPA3Transition:
+		return "wpa2-wpa3"
+	case wlan.Security == "wpapsk" && wlan.WPA3Support:
+		return "wpa3"
+	case wlan.Security == "wpapsk":
+		return "wpa2"
+	case wlan.Security == "wpaeap" && wlan.WPA3Support:
+		return "wpa3-enterprise"
+	case wlan.Security == "wpaeap":
+		return "wpa2-enterprise"
+	}
+	return wlan.Security
+}
+
+// nonNilStrings returns an empty slice in place of a nil slice so it is stored as an empty collection rather than null.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func (r *wlanResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *wlanResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_wlan"
+}
+
+// Schema defines the schema for the resource.
+func (r *wlanResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ap_group_ids": schema.SetAttribute{
+				Computed:    true,
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"band_steering": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"client_isolation": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"enabled": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"hide_ssid": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"mac_filter_enabled": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"mac_filter_list": schema.SetAttribute{
+				Computed:    true,
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(macAddressValidator{}),
+				},
+			},
+			"mac_filter_policy": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("allow", "deny"),
+				},
+			},
+			"minimum_data_rate_2g_kbps": schema.Int32Attribute{
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.OneOf(1000, 2000, 5500, 6000, 9000, 11000, 12000, 18000, 24000, 36000, 48000, 54000),
+				},
+			},
+			"minimum_data_rate_5g_kbps": schema.Int32Attribute{
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.OneOf(6000, 9000, 12000, 18000, 24000, 36000, 48000, 54000),
+				},
+			},
+			"network_id": schema.StringAttribute{
+				Required: true,
+			},
+			"passphrase": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(8, 63),
+				},
+			},
+			"radius_profile_id": schema.StringAttribute{
+				Optional: true,
+			},
+			"schedules": schema.ListNestedAttribute{
+				Computed: true,
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"days_of_week": schema.ListAttribute{
+							Required:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.ValueStringsAre(
+									stringvalidator.OneOf("sun", "mon", "tue", "wed", "thu", "fri", "sat"),
+								),
+							},
+						},
+						"duration_minutes": schema.Int32Attribute{
+							Required: true,
+							Validators: []validator.Int32{
+								int32validator.Between(1, 1440),
+							},
+						},
+						"start_hour": schema.Int32Attribute{
+							Required: true,
+							Validators: []validator.Int32{
+								int32validator.Between(0, 23),
+							},
+						},
+						"start_minute": schema.Int32Attribute{
+							Required: true,
+							Validators: []validator.Int32{
+								int32validator.Between(0, 59),
+							},
+						},
+					},
+				},
+			},
+			"security": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("open", "wpa2", "wpa3", "wpa2-wpa3", "wpa2-enterprise", "wpa3-enterprise"),
+				},
+			},
+			"site": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+			},
+			"ssid": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 32),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the credentials required by the security mode of the WLAN are configured.
+func (r *wlanResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config wlanResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || config.Security.IsUnknown() {
+		return
+	}
+
+	mode := wlanSecurityModes[config.Security.ValueString()]
+	switch {
+	case mode.security == "wpapsk" && config.Passphrase.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("passphrase"),
+			"Missing Passphrase",
+			fmt.Sprintf("A passphrase is required for WLANs with a security mode of '%s'.", config.Security.ValueString()),
+		)
+	case mode.security != "wpapsk" && !config.Passphrase.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("passphrase"),
+			"Unexpected Passphrase",
+			fmt.Sprintf("A passphrase cannot be set for WLANs with a security mode of '%s'.", config.Security.ValueString()),
+		)
+	}
+	if mode.security == "wpaeap" && config.RADIUSProfileID.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("radius_profile_id"),
+			"Missing RADIUS Profile",
+			fmt.Sprintf("A RADIUS profile is required for WLANs with a security mode of '%s'.",
+				config.Security.ValueString()),
+		)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *wlanResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan wlanResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// generate API request body from plan - new WLANs are enabled and filter MAC addresses with a deny list unless
+	// stated otherwise
+	wlan := api.WLAN{
+		Enabled:         true,
+		MACFilterPolicy: "deny",
+	}
+	resp.Diagnostics.Append(plan.applyTo(ctx, &wlan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the WLAN
+	site := siteFromModel(plan.Site, r.client)
+	createdWLAN, err := r.client.CreateWLAN(ctx, site, wlan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Create WLAN",
+			fmt.Sprintf("Failed to create WLAN using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	resp.Diagnostics.Append(plan.readFrom(ctx, createdWLAN)...)
+	plan.Site = types.StringValue(site)
+
+	// save state with the populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *wlanResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// retrieve the current state
+	var state wlanResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// refresh value from the API
+	site := siteFromModel(state.Site, r.client)
+	wlan, err := r.client.GetWLAN(ctx, site, state.ID.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		// the WLAN was removed outside of Terraform so drop it from the state to have it recreated
+		tflog.Warn(ctx, "WLAN no longer exists - removing from state", map[string]any{
+			"id":   state.ID.ValueString(),
+			"site": site,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve WLAN",
+			fmt.Sprintf("Failed to retrieve the WLAN with the ID '%s': %s",
+				state.ID.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// update the state
+	resp.Diagnostics.Append(state.readFrom(ctx, wlan)...)
+	state.Site = types.StringValue(site)
+
+	// set the refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *wlanResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan wlanResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// start from the current WLAN so fields not managed by Terraform are preserved
+	site := siteFromModel(plan.Site, r.client)
+	wlan, err := r.client.GetWLAN(ctx, site, plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Retrieve WLAN",
+			fmt.Sprintf("Failed to retrieve the WLAN with the ID '%s': %s",
+				plan.ID.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// generate API request body from plan
+	resp.Diagnostics.Append(plan.applyTo(ctx, &wlan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the WLAN
+	updatedWLAN, err := r.client.UpdateWLAN(ctx, site, plan.ID.ValueString(), wlan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Update WLAN",
+			fmt.Sprintf("Failed to update WLAN using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// map the response to the model
+	resp.Diagnostics.Append(plan.readFrom(ctx, updatedWLAN)...)
+	plan.Site = types.StringValue(site)
+
+	// save state with the populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *wlanResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// retrieve the current state
+	var state wlanResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the WLAN
+	if err := r.client.DeleteWLAN(ctx, siteFromModel(state.Site, r.client), state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"UDM API: Failed to Delete WLAN",
+			fmt.Sprintf("Failed to delete WLAN using the UDM API:\n\t%s", apiErrorDetail(err)),
+		)
+		return
+	}
+}
+
+// ModifyPlan sets the planned site of the WLAN and replaces the WLAN when it is moved to another site.
+func (r *wlanResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifySitePlan(ctx, r.client, req, resp)
+}
+
+// ImportState imports a WLAN by its ID or SSID, optionally prefixed by its site (ie: "site/id" or "site/ssid").
+func (r *wlanResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	site, id, ok := parseImportID(r.client, req, resp)
+	if !ok {
+		return
+	}
+
+	// look up the ID of the WLAN if it was imported by its SSID
+	if !isObjectID(id) {
+		wlans, err := r.client.GetWLANs(ctx, site)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"UDM API: Failed to Retrieve WLANs",
+				fmt.Sprintf("Failed to retrieve WLANs from the UDM API:\n\t%s", apiErrorDetail(err)),
+			)
+			return
+		}
+		ssid := id
+		id = ""
+		for _, wlan := range wlans {
+			if wlan.Name == ssid {
+				id = wlan.ID
+				break
+			}
+		}
+		if id == "" {
+			resp.Diagnostics.AddError(
+				"WLAN Not Found",
+				fmt.Sprintf("No WLAN with the SSID '%s' exists in the site '%s'.", ssid, site),
+			)
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), site)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
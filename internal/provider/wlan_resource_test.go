@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.joshhogle.dev/terraform-provider-ubiquiti-udm/internal/udmmock"
+)
+
+const testAccWLANNetworkConfig = `
+resource "udm_network" "guest" {
+  name    = "Guest"
+  purpose = "guest"
+  vlan_id = 30
+}
+`
+
+func TestAccWLANResource(t *testing.T) {
+	server, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCollectionEmpty(server, udmmock.DefaultSite, udmmock.CollectionWLANs),
+		Steps: []resource.TestStep{
+			// create and read
+			{
+				Config: providerConfig + testAccWLANNetworkConfig + `
+resource "udm_wlan" "test" {
+  ssid                      = "Guest Wi-Fi"
+  security                  = "wpa2-wpa3"
+  passphrase                = "correct horse battery"
+  network_id                = udm_network.guest.id
+  client_isolation          = true
+  minimum_data_rate_2g_kbps = 6000
+  schedules = [
+    {
+      days_of_week     = ["sat", "sun"]
+      start_hour       = 8
+      start_minute     = 30
+      duration_minutes = 600
+    },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("udm_wlan.test", "id"),
+					resource.TestCheckResourceAttr("udm_wlan.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("udm_wlan.test", "hide_ssid", "false"),
+					resource.TestCheckResourceAttr("udm_wlan.test", "mac_filter_policy", "deny"),
+					resource.TestCheckResourceAttr("udm_wlan.test", "schedules.0.days_of_week.1", "sun"),
+					resource.TestCheckResourceAttrPair("udm_wlan.test", "network_id", "udm_network.guest", "id"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionWLANs,
+						"udm_wlan.test", "security", "wpapsk"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionWLANs,
+						"udm_wlan.test", "wpa3_transition", true),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionWLANs,
+						"udm_wlan.test", "l2_isolation", true),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionWLANs,
+						"udm_wlan.test", "schedule_enabled", true),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionWLANs,
+						"udm_wlan.test", "minrate_ng_enabled", true),
+				),
+			},
+			// import by ID
+			{
+				ResourceName:      "udm_wlan.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// import by SSID
+			{
+				ResourceName:      "udm_wlan.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return "Guest Wi-Fi", nil
+				},
+			},
+			// rotate the passphrase, hide the SSID and filter MAC addresses
+			{
+				Config: providerConfig + testAccWLANNetworkConfig + `
+resource "udm_wlan" "test" {
+  ssid               = "Guest Wi-Fi"
+  security           = "wpa3"
+  passphrase         = "staple battery horse"
+  network_id         = udm_network.guest.id
+  hide_ssid          = true
+  mac_filter_enabled = true
+  mac_filter_list    = ["00:11:22:aa:bb:cc"]
+  mac_filter_policy  = "allow"
+  schedules          = []
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("udm_wlan.test", "passphrase", "staple battery horse"),
+					resource.TestCheckResourceAttr("udm_wlan.test", "schedules.#", "0"),
+					resource.TestCheckNoResourceAttr("udm_wlan.test", "minimum_data_rate_2g_kbps"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionWLANs,
+						"udm_wlan.test", "x_passphrase", "staple battery horse"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionWLANs,
+						"udm_wlan.test", "wpa3_transition", false),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionWLANs,
+						"udm_wlan.test", "mac_filter_policy", "allow"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionWLANs,
+						"udm_wlan.test", "schedule_enabled", false),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionWLANs,
+						"udm_wlan.test", "minrate_ng_enabled", false),
+				),
+			},
+			// recreate after the WLAN was deleted outside of Terraform
+			{
+				PreConfig: func() {
+					testAccDeleteAllObjects(server, udmmock.DefaultSite, udmmock.CollectionWLANs)
+				},
+				Config: providerConfig + testAccWLANNetworkConfig + `
+resource "udm_wlan" "test" {
+  ssid       = "Guest Wi-Fi"
+  security   = "open"
+  network_id = udm_network.guest.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("udm_wlan.test", "passphrase"),
+					testAccCheckObjectAttr(server, udmmock.DefaultSite, udmmock.CollectionWLANs,
+						"udm_wlan.test", "security", "open"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWLANResourceValidation(t *testing.T) {
+	_, providerConfig := newTestAccServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "udm_wlan" "test" {
+  ssid       = "Home"
+  security   = "wpa2"
+  network_id = "000000000000000000000000"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing Passphrase`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_wlan" "test" {
+  ssid       = "Corp"
+  security   = "wpa3-enterprise"
+  network_id = "000000000000000000000000"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing RADIUS Profile`),
+			},
+			{
+				Config: providerConfig + `
+resource "udm_wlan" "test" {
+  ssid            = "Home"
+  security        = "wpa2"
+  passphrase      = "correct horse battery"
+  network_id      = "000000000000000000000000"
+  mac_filter_list = ["00-11-22-AA-BB-CC"]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid MAC Address`),
+			},
+		},
+	})
+}
@@ -0,0 +1,190 @@
+package udmmock
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 -- RFC 6238 TOTP codes are generated with HMAC-SHA1
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// session is a session issued by the login endpoint.
+type session struct {
+	csrfToken string
+	expiresAt time.Time
+}
+
+type jwtClaims struct {
+	jwt.RegisteredClaims
+
+	UserID           string `json:"userId"`
+	PasswordRevision int64  `json:"passwordRevision"`
+	IsRemembered     bool   `json:"isRemembered"`
+	CsrfToken        string `json:"csrfToken"`
+}
+
+type loginRequest struct {
+	Password   string `json:"password"`
+	RememberMe bool   `json:"rememberMe"`
+	Token      string `json:"token"`
+	Username   string `json:"username"`
+}
+
+// ExpireSessions invalidates every session issued so far, as happens when the UDM restarts.
+func (s *Server) ExpireSessions() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	clear(s.sessions)
+}
+
+// handleLogin handles POST /api/auth/login.
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var body loginRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	if body.Username != s.config.Username || body.Password != s.config.Password {
+		writeJSON(w, http.StatusUnauthorized, map[string]any{
+			"code":    "AUTHENTICATION_FAILED_INVALID_CREDENTIALS",
+			"level":   "error",
+			"message": "Invalid username or password",
+		})
+		return
+	}
+	if s.config.TOTPSecret != "" {
+		if body.Token == "" {
+			writeJSON(w, 499, map[string]any{
+				"code":    "MFA_AUTH_REQUIRED",
+				"level":   "error",
+				"message": "Multi-factor authentication required",
+			})
+			return
+		}
+		if !validTOTP(s.config.TOTPSecret, body.Token, time.Now()) {
+			writeJSON(w, http.StatusUnauthorized, map[string]any{
+				"code":    "AUTHENTICATION_FAILED_INVALID_MFA_TOKEN",
+				"level":   "error",
+				"message": "Invalid MFA token",
+			})
+			return
+		}
+	}
+
+	s.lock.Lock()
+	userID := s.newID()
+	sess := session{
+		csrfToken: s.newID(),
+		expiresAt: time.Now().Add(s.config.SessionLifetime).Truncate(time.Second),
+	}
+	s.lock.Unlock()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(sess.expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+		UserID:       userID,
+		CsrfToken:    sess.csrfToken,
+		IsRemembered: body.RememberMe,
+	}).SignedString(s.jwtKey)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]any{
+			"code":    "INTERNAL_ERROR",
+			"message": err.Error(),
+		})
+		return
+	}
+	s.lock.Lock()
+	s.sessions[token] = sess
+	s.lock.Unlock()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     JWTCookieName,
+		Value:    token,
+		Path:     "/",
+		Expires:  sess.expiresAt,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteNoneMode,
+	})
+	writeJSON(w, http.StatusOK, map[string]any{
+		"unique_id":           userID,
+		"first_name":          "",
+		"last_name":           "",
+		"full_name":           "",
+		"email":               fmt.Sprintf("%s@example.com", s.config.Username),
+		"email_status":        "VERIFIED",
+		"phone":               "",
+		"username":            s.config.Username,
+		"local_account_exist": true,
+		"deviceToken":         s.newSecret(),
+		"isOwner":             true,
+		"isSuperAdmin":        true,
+	})
+}
+
+// authenticated wraps a handler so that it is only called for requests with a valid API key or session.
+//
+// Like the UDM, requests other than GETs made with a session must also carry the session's CSRF token.
+func (s *Server) authenticated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.config.APIKey != "" && r.Header.Get("X-API-KEY") == s.config.APIKey {
+			next(w, r)
+			return
+		}
+		if cookie, err := r.Cookie(JWTCookieName); err == nil {
+			s.lock.Lock()
+			sess, ok := s.sessions[cookie.Value]
+			s.lock.Unlock()
+			if ok && time.Now().Before(sess.expiresAt) {
+				if r.Method != http.MethodGet && r.Header.Get("X-Csrf-Token") != sess.csrfToken {
+					writeJSON(w, http.StatusForbidden, map[string]any{
+						"code":    "CSRF_TOKEN_INVALID",
+						"message": "Invalid CSRF token",
+					})
+					return
+				}
+				next(w, r)
+				return
+			}
+		}
+		writeJSON(w, http.StatusUnauthorized, map[string]any{
+			"code":    "AUTHENTICATION_FAILED_UNAUTHORIZED",
+			"message": "Unauthorized",
+		})
+	}
+}
+
+// newSecret returns a random-looking opaque token.
+func (s *Server) newSecret() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return strings.Repeat(s.newID(), 2)
+}
+
+// validTOTP returns whether or not code is a valid TOTP code for the secret at time t, allowing one period of clock
+// drift in either direction.
+func validTOTP(secret, code string, t time.Time) bool {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(
+		strings.TrimRight(strings.ToUpper(strings.ReplaceAll(secret, " ", "")), "="))
+	if err != nil {
+		return false
+	}
+	for _, drift := range []int64{-1, 0, 1} {
+		counter := make([]byte, 8)
+		binary.BigEndian.PutUint64(counter, uint64(t.Unix()/30+drift))
+		mac := hmac.New(sha1.New, key)
+		mac.Write(counter)
+		sum := mac.Sum(nil)
+		offset := sum[len(sum)-1] & 0x0f
+		value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+		if fmt.Sprintf("%06d", value%1000000) == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,357 @@
+package udmmock
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+)
+
+// defaultFirewallZones are the built-in zones of the zone-based firewall created in every site, keyed by zone key.
+var defaultFirewallZones = []struct {
+	key  string
+	name string
+}{
+	{"internal", "Internal"},
+	{"external", "External"},
+	{"gateway", "Gateway"},
+	{"vpn", "VPN"},
+	{"hotspot", "Hotspot"},
+	{"dmz", "DMZ"},
+}
+
+// addDefaultFirewallZones adds the built-in zones of the zone-based firewall to a site.
+//
+// The caller must hold s.lock unless the server has not been started yet.
+func (s *Server) addDefaultFirewallZones(st *site) {
+	for _, zone := range defaultFirewallZones {
+		st.insert(CollectionFirewallZones, s.newID(), map[string]any{
+			"default_zone": true,
+			"name":         zone.name,
+			"network_ids":  []any{},
+			"zone_key":     zone.key,
+		})
+	}
+}
+
+// internalFirewallZone returns the built-in zone holding networks which have not been assigned to another zone.
+func (st *site) internalFirewallZone() map[string]any {
+	for _, zone := range st.collections[CollectionFirewallZones] {
+		if zone["zone_key"] == "internal" {
+			return zone
+		}
+	}
+	return nil
+}
+
+// assignNetworksToFirewallZone moves networks from whichever zone they are in to the given zone.
+func (st *site) assignNetworksToFirewallZone(zone map[string]any, networkIDs []any) {
+	for _, other := range st.collections[CollectionFirewallZones] {
+		if other["_id"] == zone["_id"] {
+			continue
+		}
+		ids, _ := other["network_ids"].([]any)
+		other["network_ids"] = slices.DeleteFunc(slices.Clone(ids), func(id any) bool {
+			return slices.Contains(networkIDs, id)
+		})
+	}
+	ids, _ := zone["network_ids"].([]any)
+	for _, id := range networkIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	zone["network_ids"] = ids
+}
+
+// removeNetworkFromFirewallZones removes a deleted network from the zones.
+func (st *site) removeNetworkFromFirewallZones(networkID string) {
+	for _, zone := range st.collections[CollectionFirewallZones] {
+		ids, _ := zone["network_ids"].([]any)
+		zone["network_ids"] = slices.DeleteFunc(slices.Clone(ids), func(id any) bool {
+			return id == networkID
+		})
+	}
+}
+
+// validateFirewallZone checks a firewall zone the same way the UDM would, returning the error code and message to send
+// back if it is invalid.
+func validateFirewallZone(st *site, zone map[string]any) (string, string) {
+	name, _ := zone["name"].(string)
+	if name == "" {
+		return "api.err.InvalidPayload", "name is required"
+	}
+	ids, _ := zone["network_ids"].([]any)
+	for _, id := range ids {
+		if networkID, _ := id.(string); st.find(CollectionNetworks, networkID) == nil {
+			return "api.err.InvalidPayload", fmt.Sprintf("network %v does not exist", id)
+		}
+	}
+	for _, existing := range st.collections[CollectionFirewallZones] {
+		if existing["_id"] != zone["_id"] && existing["name"] == name {
+			return "api.err.FirewallZoneNameExists", "a zone with the same name already exists"
+		}
+	}
+	return "", ""
+}
+
+// validateFirewallPolicy checks a firewall policy the same way the UDM would, returning the error message to send back
+// if it is invalid.
+func validateFirewallPolicy(st *site, policy map[string]any) string {
+	if name, _ := policy["name"].(string); name == "" {
+		return "name is required"
+	}
+	switch policy["action"] {
+	case "ALLOW", "BLOCK", "REJECT":
+	default:
+		return "action is invalid"
+	}
+	switch policy["ip_version"] {
+	case "BOTH", "IPV4", "IPV6":
+	default:
+		return "ip_version is invalid"
+	}
+	for _, field := range []string{"source", "destination"} {
+		endpoint, _ := policy[field].(map[string]any)
+		zoneID, _ := endpoint["zone_id"].(string)
+		if st.find(CollectionFirewallZones, zoneID) == nil {
+			return fmt.Sprintf("%s.zone_id is invalid", field)
+		}
+	}
+	return ""
+}
+
+// firewallZoneInUse returns whether or not a zone is the source or destination of a firewall policy.
+func (st *site) firewallZoneInUse(id string) bool {
+	for _, policy := range st.collections[CollectionFirewallPolicies] {
+		for _, field := range []string{"source", "destination"} {
+			if endpoint, _ := policy[field].(map[string]any); endpoint["zone_id"] == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nextFirewallPolicyIndex returns the index following the last policy between the same source and destination zones.
+func (st *site) nextFirewallPolicyIndex(policy map[string]any) float64 {
+	source, _ := policy["source"].(map[string]any)
+	destination, _ := policy["destination"].(map[string]any)
+	index := float64(10000)
+	for _, existing := range st.collections[CollectionFirewallPolicies] {
+		existingSource, _ := existing["source"].(map[string]any)
+		existingDestination, _ := existing["destination"].(map[string]any)
+		if existingSource["zone_id"] != source["zone_id"] || existingDestination["zone_id"] != destination["zone_id"] {
+			continue
+		}
+		if i, _ := existing["index"].(float64); i >= index {
+			index = i + 1
+		}
+	}
+	return index
+}
+
+// handleListFirewallZones handles GET /proxy/network/v2/api/site/:site/firewall/zone.
+func (s *Server) handleListFirewallZones(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if st := s.v2Site(w, r); st != nil {
+		writeJSON(w, http.StatusOK, st.list(CollectionFirewallZones))
+	}
+}
+
+// handleCreateFirewallZone handles POST /proxy/network/v2/api/site/:site/firewall/zone.
+//
+// Networks assigned to the new zone are removed from the zone they were in.
+func (s *Server) handleCreateFirewallZone(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.v2Site(w, r)
+	if st == nil {
+		return
+	}
+	delete(body, "_id")
+	if code, msg := validateFirewallZone(st, body); code != "" {
+		writeV2Error(w, http.StatusBadRequest, code, msg)
+		return
+	}
+	body["default_zone"] = false
+	body["zone_key"] = ""
+	networkIDs, _ := body["network_ids"].([]any)
+	body["network_ids"] = []any{}
+	zone := st.insert(CollectionFirewallZones, s.newID(), body)
+	st.assignNetworksToFirewallZone(zone, networkIDs)
+	writeJSON(w, http.StatusOK, zone)
+}
+
+// handleUpdateFirewallZone handles PUT /proxy/network/v2/api/site/:site/firewall/zone/:id.
+//
+// Networks removed from a custom zone are returned to the internal zone.
+func (s *Server) handleUpdateFirewallZone(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.v2Site(w, r)
+	if st == nil {
+		return
+	}
+	zone := st.find(CollectionFirewallZones, r.PathValue("id"))
+	if zone == nil {
+		writeV2Error(w, http.StatusNotFound, "api.err.NotFound", "firewall zone not found")
+		return
+	}
+	body["_id"] = zone["_id"]
+	if code, msg := validateFirewallZone(st, body); code != "" {
+		writeV2Error(w, http.StatusBadRequest, code, msg)
+		return
+	}
+	if zone["default_zone"] == true && body["name"] != zone["name"] {
+		writeV2Error(w, http.StatusBadRequest, "api.err.FirewallZoneNotEditable", "built-in zones cannot be renamed")
+		return
+	}
+	networkIDs, _ := body["network_ids"].([]any)
+	oldNetworkIDs, _ := zone["network_ids"].([]any)
+	zone["name"] = body["name"]
+	zone["network_ids"] = []any{}
+	st.assignNetworksToFirewallZone(zone, networkIDs)
+	if zone["zone_key"] != "internal" {
+		removed := slices.DeleteFunc(slices.Clone(oldNetworkIDs), func(id any) bool {
+			return slices.Contains(networkIDs, id)
+		})
+		st.assignNetworksToFirewallZone(st.internalFirewallZone(), removed)
+	}
+	writeJSON(w, http.StatusOK, maps.Clone(zone))
+}
+
+// handleDeleteFirewallZone handles DELETE /proxy/network/v2/api/site/:site/firewall/zone/:id.
+//
+// The networks of the zone are returned to the internal zone.
+func (s *Server) handleDeleteFirewallZone(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.v2Site(w, r)
+	if st == nil {
+		return
+	}
+	id := r.PathValue("id")
+	zone := st.find(CollectionFirewallZones, id)
+	switch {
+	case zone == nil:
+		writeV2Error(w, http.StatusNotFound, "api.err.NotFound", "firewall zone not found")
+		return
+	case zone["default_zone"] == true:
+		writeV2Error(w, http.StatusBadRequest, "api.err.FirewallZoneNotDeletable", "built-in zones cannot be deleted")
+		return
+	case st.firewallZoneInUse(id):
+		writeV2Error(w, http.StatusBadRequest, "api.err.FirewallZoneInUse", "the zone is used by a firewall policy")
+		return
+	}
+	networkIDs, _ := zone["network_ids"].([]any)
+	st.assignNetworksToFirewallZone(st.internalFirewallZone(), networkIDs)
+	st.remove(CollectionFirewallZones, id)
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+// handleListFirewallPolicies handles GET /proxy/network/v2/api/site/:site/firewall-policies.
+func (s *Server) handleListFirewallPolicies(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if st := s.v2Site(w, r); st != nil {
+		writeJSON(w, http.StatusOK, st.list(CollectionFirewallPolicies))
+	}
+}
+
+// handleCreateFirewallPolicy handles POST /proxy/network/v2/api/site/:site/firewall-policies.
+//
+// Policies without an index are placed after the other policies between the same zones.
+func (s *Server) handleCreateFirewallPolicy(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.v2Site(w, r)
+	if st == nil {
+		return
+	}
+	if msg := validateFirewallPolicy(st, body); msg != "" {
+		writeV2Error(w, http.StatusBadRequest, "api.err.InvalidPayload", msg)
+		return
+	}
+	if index, _ := body["index"].(float64); index == 0 {
+		body["index"] = st.nextFirewallPolicyIndex(body)
+	}
+	body["predefined"] = false
+	writeJSON(w, http.StatusOK, st.insert(CollectionFirewallPolicies, s.newID(), body))
+}
+
+// handleUpdateFirewallPolicy handles PUT /proxy/network/v2/api/site/:site/firewall-policies/:id.
+//
+// Like the other v2 collections, the request body replaces the whole object.
+func (s *Server) handleUpdateFirewallPolicy(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.v2Site(w, r)
+	if st == nil {
+		return
+	}
+	policy := st.find(CollectionFirewallPolicies, r.PathValue("id"))
+	if policy == nil {
+		writeV2Error(w, http.StatusNotFound, "api.err.NotFound", "firewall policy not found")
+		return
+	}
+	if policy["predefined"] == true {
+		writeV2Error(w, http.StatusBadRequest, "api.err.FirewallPolicyNotEditable", "predefined policies cannot be edited")
+		return
+	}
+	if msg := validateFirewallPolicy(st, body); msg != "" {
+		writeV2Error(w, http.StatusBadRequest, "api.err.InvalidPayload", msg)
+		return
+	}
+	if index, _ := body["index"].(float64); index == 0 {
+		body["index"] = policy["index"]
+	}
+	id, siteID := policy["_id"], policy["site_id"]
+	clear(policy)
+	maps.Copy(policy, body)
+	policy["_id"], policy["site_id"], policy["predefined"] = id, siteID, false
+	writeJSON(w, http.StatusOK, maps.Clone(policy))
+}
+
+// handleBatchDeleteFirewallPolicies handles POST /proxy/network/v2/api/site/:site/firewall-policies/batch-delete.
+//
+// Nothing is deleted unless every policy exists.
+func (s *Server) handleBatchDeleteFirewallPolicies(w http.ResponseWriter, r *http.Request) {
+	var ids []string
+	if !decodeJSON(w, r, &ids) {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.v2Site(w, r)
+	if st == nil {
+		return
+	}
+	for _, id := range ids {
+		if st.find(CollectionFirewallPolicies, id) == nil {
+			writeV2Error(w, http.StatusNotFound, "api.err.NotFound", "firewall policy not found")
+			return
+		}
+	}
+	for _, id := range ids {
+		st.remove(CollectionFirewallPolicies, id)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
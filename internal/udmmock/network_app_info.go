@@ -0,0 +1,31 @@
+package udmmock
+
+import (
+	"net/http"
+	"time"
+)
+
+// handleGetNetworkAppInfo handles GET /proxy/network/v2/api/info.
+func (s *Server) handleGetNetworkAppInfo(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"system": map[string]any{
+			"device_id": "00000000-0000-0000-0000-000000000000",
+			"host_meta": map[string]any{
+				"id":                 "00000000-0000-0000-0000-000000000000",
+				"model_abbreviation": "UDM",
+				"model_fullName":     "UniFi Dream Machine Mock",
+				"model_name":         "UniFi Dream Machine",
+				"model_sysid":        "ea11",
+				"sku":                "UDM-MOCK",
+			},
+			"hostname": "udm-mock",
+			"name":     "UDM Mock",
+			"unifi_console": map[string]any{
+				"type":    "UDM",
+				"version": "4.1.13",
+			},
+			"uptime":  uint64(time.Since(s.startedAt).Seconds()),
+			"version": s.config.NetworkAppVersion,
+		},
+	})
+}
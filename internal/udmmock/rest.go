@@ -0,0 +1,427 @@
+package udmmock
+
+import (
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// firewallRulesets are the rulesets of the legacy firewall which accept user-defined rules.
+var firewallRulesets = []string{
+	"GUEST_IN", "GUEST_LOCAL", "GUEST_OUT", "GUESTv6_IN", "GUESTv6_LOCAL", "GUESTv6_OUT",
+	"LAN_IN", "LAN_LOCAL", "LAN_OUT", "LANv6_IN", "LANv6_LOCAL", "LANv6_OUT",
+	"WAN_IN", "WAN_LOCAL", "WAN_OUT", "WANv6_IN", "WANv6_LOCAL", "WANv6_OUT",
+}
+
+// portForwardInterfaces are the WAN interfaces a port forward can listen on.
+var portForwardInterfaces = []any{"both", "wan", "wan2"}
+
+// portForwardProtocols are the protocols a port forward can forward.
+var portForwardProtocols = []any{"tcp", "tcp_udp", "udp"}
+
+type siteManagerRequest struct {
+	Command     string `json:"cmd"`
+	Description string `json:"desc"`
+	Site        string `json:"site"`
+}
+
+type stationManagerRequest struct {
+	Command           string   `json:"cmd"`
+	HardwareAddresses []string `json:"macs"`
+}
+
+// writeV1 writes a successful v1 API response containing the given objects.
+func writeV1(w http.ResponseWriter, data ...map[string]any) {
+	if data == nil {
+		data = []map[string]any{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"meta": map[string]any{"rc": "ok"},
+		"data": data,
+	})
+}
+
+// writeV1Error writes a failed v1 API response with the given message (eg: api.err.IdInvalid).
+func writeV1Error(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]any{
+		"meta": map[string]any{"rc": "error", "msg": message},
+		"data": []any{},
+	})
+}
+
+// v1Site returns the site named in the request path, writing an error response and returning nil if it does not
+// exist.
+//
+// The caller must hold s.lock.
+func (s *Server) v1Site(w http.ResponseWriter, r *http.Request) *site {
+	st, ok := s.sites[r.PathValue("site")]
+	if !ok {
+		writeV1Error(w, http.StatusBadRequest, "api.err.NoSiteContext")
+		return nil
+	}
+	return st
+}
+
+// validateV1 checks an object about to be stored in a v1 collection the same way the UDM would, returning the error
+// message to send back if it is invalid.
+//
+// The caller must hold s.lock.
+func validateV1(st *site, collection string, object map[string]any) string {
+	switch collection {
+	case CollectionClientDevices:
+		mac, _ := object["mac"].(string)
+		if mac == "" {
+			return "api.err.InvalidMac"
+		}
+		mac = strings.ToLower(mac)
+		object["mac"] = mac
+		for _, existing := range st.collections[collection] {
+			if existing["mac"] == mac && existing["_id"] != object["_id"] {
+				return "api.err.MacUsed"
+			}
+		}
+	case CollectionFirewallGroups:
+		if name, _ := object["name"].(string); name == "" {
+			return "api.err.InvalidName"
+		}
+		switch object["group_type"] {
+		case "address-group", "ipv6-address-group", "port-group":
+		default:
+			return "api.err.InvalidGroupType"
+		}
+		for _, existing := range st.collections[collection] {
+			if existing["_id"] != object["_id"] && existing["name"] == object["name"] {
+				return "api.err.FirewallGroupExisted"
+			}
+		}
+	case CollectionFirewallRules:
+		if name, _ := object["name"].(string); name == "" {
+			return "api.err.InvalidName"
+		}
+		ruleset, _ := object["ruleset"].(string)
+		if !slices.Contains(firewallRulesets, ruleset) {
+			return "api.err.InvalidRuleset"
+		}
+		switch object["action"] {
+		case "accept", "drop", "reject":
+		default:
+			return "api.err.InvalidAction"
+		}
+		index, _ := object["rule_index"].(float64)
+		if (index < 2000 || index > 2999) && (index < 4000 || index > 4999) {
+			return "api.err.FirewallRuleIndexOutOfRange"
+		}
+		for _, existing := range st.collections[collection] {
+			if existing["_id"] != object["_id"] && existing["ruleset"] == ruleset && existing["rule_index"] == index {
+				return "api.err.FirewallRuleIndexExisted"
+			}
+		}
+	case CollectionNetworks:
+		if name, _ := object["name"].(string); name == "" {
+			return "api.err.InvalidName"
+		}
+		switch object["purpose"] {
+		case "corporate", "guest", "vlan-only", "wan":
+		default:
+			return "api.err.InvalidPurpose"
+		}
+		for _, existing := range st.collections[collection] {
+			if existing["_id"] == object["_id"] {
+				continue
+			}
+			if existing["name"] == object["name"] {
+				return "api.err.DuplicateNetworkName"
+			}
+			if object["vlan_enabled"] == true && existing["vlan_enabled"] == true && existing["vlan"] == object["vlan"] {
+				return "api.err.VlanUsed"
+			}
+		}
+	case CollectionPortForwards:
+		if name, _ := object["name"].(string); name == "" {
+			return "api.err.InvalidName"
+		}
+		if !slices.Contains(portForwardInterfaces, object["pfwd_interface"]) {
+			return "api.err.InvalidPfwdInterface"
+		}
+		if !slices.Contains(portForwardProtocols, object["proto"]) {
+			return "api.err.InvalidProto"
+		}
+		if fwd, _ := object["fwd"].(string); net.ParseIP(fwd).To4() == nil {
+			return "api.err.InvalidFwd"
+		}
+		if port, _ := object["dst_port"].(string); port == "" {
+			return "api.err.InvalidDstPort"
+		}
+		if port, _ := object["fwd_port"].(string); port == "" {
+			return "api.err.InvalidFwdPort"
+		}
+		if object["src_limiting_enabled"] == true && object["src_limiting_type"] == "firewall_group" {
+			groupID, _ := object["src_firewall_group_id"].(string)
+			if st.find(CollectionFirewallGroups, groupID) == nil {
+				return "api.err.InvalidFirewallGroup"
+			}
+		}
+		for _, existing := range st.collections[collection] {
+			if existing["_id"] != object["_id"] && existing["dst_port"] == object["dst_port"] &&
+				overlaps(existing["pfwd_interface"], object["pfwd_interface"], "both") &&
+				overlaps(existing["proto"], object["proto"], "tcp_udp") {
+
+				return "api.err.PortForwardDstPortUsed"
+			}
+		}
+	case CollectionWLANs:
+		if name, _ := object["name"].(string); name == "" || len(name) > 32 {
+			return "api.err.InvalidSsid"
+		}
+		switch object["security"] {
+		case "open":
+		case "wpapsk":
+			if passphrase, _ := object["x_passphrase"].(string); len(passphrase) < 8 || len(passphrase) > 63 {
+				return "api.err.InvalidPassphrase"
+			}
+		case "wpaeap":
+			if id, _ := object["radiusprofile_id"].(string); id == "" {
+				return "api.err.InvalidRadiusProfile"
+			}
+		default:
+			return "api.err.InvalidSecurity"
+		}
+		networkID, _ := object["networkconf_id"].(string)
+		if st.find(CollectionNetworks, networkID) == nil {
+			return "api.err.InvalidNetworkconfId"
+		}
+		for _, existing := range st.collections[collection] {
+			if existing["_id"] != object["_id"] && existing["name"] == object["name"] {
+				return "api.err.DuplicateSsid"
+			}
+		}
+	}
+	return ""
+}
+
+// overlaps returns whether or not two settings which are either a single value or a value covering all of them (eg:
+// the "both" WAN interface) apply to any of the same things.
+func overlaps(a, b any, all string) bool {
+	return a == b || a == all || b == all
+}
+
+// firewallGroupInUse returns whether or not a firewall group is referenced by a firewall rule or port forward, which
+// prevents the UDM from deleting it.
+//
+// The caller must hold s.lock.
+func (st *site) firewallGroupInUse(id string) bool {
+	for _, rule := range st.collections[CollectionFirewallRules] {
+		for _, field := range []string{"src_firewallgroup_ids", "dst_firewallgroup_ids"} {
+			ids, _ := rule[field].([]any)
+			if slices.Contains(ids, any(id)) {
+				return true
+			}
+		}
+	}
+	for _, portForward := range st.collections[CollectionPortForwards] {
+		if portForward["src_firewall_group_id"] == id {
+			return true
+		}
+	}
+	return false
+}
+
+// handleListV1 handles GET /proxy/network/api/s/:site/rest/:collection.
+func (s *Server) handleListV1(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if st := s.v1Site(w, r); st != nil {
+		writeV1(w, st.list(r.PathValue("collection"))...)
+	}
+}
+
+// handleGetV1 handles GET /proxy/network/api/s/:site/rest/:collection/:id.
+func (s *Server) handleGetV1(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.v1Site(w, r)
+	if st == nil {
+		return
+	}
+	object := st.find(r.PathValue("collection"), r.PathValue("id"))
+	if object == nil {
+		writeV1Error(w, http.StatusBadRequest, "api.err.IdInvalid")
+		return
+	}
+	writeV1(w, object)
+}
+
+// handleCreateV1 handles POST /proxy/network/api/s/:site/rest/:collection.
+func (s *Server) handleCreateV1(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.v1Site(w, r)
+	if st == nil {
+		return
+	}
+	collection := r.PathValue("collection")
+	delete(body, "_id")
+	if msg := validateV1(st, collection, body); msg != "" {
+		writeV1Error(w, http.StatusBadRequest, msg)
+		return
+	}
+	object := st.insert(collection, s.newID(), body)
+
+	// new networks belong to the internal zone of the zone-based firewall until they are assigned to another zone
+	if collection == CollectionNetworks {
+		st.assignNetworksToFirewallZone(st.internalFirewallZone(), []any{object["_id"]})
+	}
+	writeV1(w, object)
+}
+
+// handleUpdateV1 handles PUT /proxy/network/api/s/:site/rest/:collection/:id.
+//
+// Like the UDM, only the fields present in the request body are changed.
+func (s *Server) handleUpdateV1(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.v1Site(w, r)
+	if st == nil {
+		return
+	}
+	collection := r.PathValue("collection")
+	object := st.find(collection, r.PathValue("id"))
+	if object == nil {
+		writeV1Error(w, http.StatusBadRequest, "api.err.IdInvalid")
+		return
+	}
+	delete(body, "_id")
+	delete(body, "site_id")
+	updated := maps.Clone(object)
+	maps.Copy(updated, body)
+	if msg := validateV1(st, collection, updated); msg != "" {
+		writeV1Error(w, http.StatusBadRequest, msg)
+		return
+	}
+	maps.Copy(object, updated)
+	writeV1(w, object)
+}
+
+// handleDeleteV1 handles DELETE /proxy/network/api/s/:site/rest/:collection/:id.
+func (s *Server) handleDeleteV1(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.v1Site(w, r)
+	if st == nil {
+		return
+	}
+	collection, id := r.PathValue("collection"), r.PathValue("id")
+	if collection == CollectionFirewallGroups && st.firewallGroupInUse(id) {
+		writeV1Error(w, http.StatusBadRequest, "api.err.FirewallGroupInUse")
+		return
+	}
+	if !st.remove(collection, id) {
+		writeV1Error(w, http.StatusBadRequest, "api.err.IdInvalid")
+		return
+	}
+	if collection == CollectionNetworks {
+		st.removeNetworkFromFirewallZones(id)
+	}
+	writeV1(w)
+}
+
+// handleStationManager handles POST /proxy/network/api/s/:site/cmd/stamgr.
+//
+// Only the forget-sta command is supported, which removes the client devices with the given MAC addresses.
+func (s *Server) handleStationManager(w http.ResponseWriter, r *http.Request) {
+	var body stationManagerRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.v1Site(w, r)
+	if st == nil {
+		return
+	}
+	if body.Command != "forget-sta" {
+		writeV1Error(w, http.StatusBadRequest, "api.err.UnknownCommand")
+		return
+	}
+	for _, mac := range body.HardwareAddresses {
+		for _, object := range st.list(CollectionClientDevices) {
+			if object["mac"] == strings.ToLower(mac) {
+				st.remove(CollectionClientDevices, object["_id"].(string))
+			}
+		}
+	}
+	writeV1(w)
+}
+
+// handleListSites handles GET /proxy/network/api/stat/sites.
+func (s *Server) handleListSites(w http.ResponseWriter, _ *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var sites []map[string]any
+	for _, st := range s.sortedSites() {
+		sites = append(sites, st.object())
+	}
+	writeV1(w, sites...)
+}
+
+// handleSiteManager handles POST /proxy/network/api/s/:site/cmd/sitemgr.
+//
+// The add-site and delete-site commands create and delete other sites while update-site changes the description of
+// the site in the request path.
+func (s *Server) handleSiteManager(w http.ResponseWriter, r *http.Request) {
+	var body siteManagerRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.v1Site(w, r)
+	if st == nil {
+		return
+	}
+	switch body.Command {
+	case "add-site":
+		if body.Description == "" {
+			writeV1Error(w, http.StatusBadRequest, "api.err.InvalidPayload")
+			return
+		}
+
+		// like the UDM, the name of a new site is generated
+		id := s.newID()
+		added := s.addSite(id[len(id)-8:], body.Description)
+		writeV1(w, added.object())
+	case "update-site":
+		if body.Description == "" {
+			writeV1Error(w, http.StatusBadRequest, "api.err.InvalidPayload")
+			return
+		}
+		st.description = body.Description
+		writeV1(w, st.object())
+	case "delete-site":
+		for _, target := range s.sites {
+			if target.id != body.Site {
+				continue
+			}
+			if target.name == DefaultSite {
+				writeV1Error(w, http.StatusBadRequest, "api.err.DefaultSiteCannotBeDeleted")
+				return
+			}
+			delete(s.sites, target.name)
+			writeV1(w)
+			return
+		}
+		writeV1Error(w, http.StatusBadRequest, "api.err.IdInvalid")
+	default:
+		writeV1Error(w, http.StatusBadRequest, "api.err.UnknownCommand")
+	}
+}
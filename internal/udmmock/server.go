@@ -0,0 +1,222 @@
+// Package udmmock implements an in-process stand-in for the API of a UniFi Dream Machine (UDM) for use in tests.
+//
+// The server speaks HTTPS with a self-signed certificate and keeps all of its state in memory.  It implements the
+// UniFi OS login endpoint, the network application info endpoint, the site manager, the v1 REST collections (eg:
+// rest/user), the v2 static DNS collection and the v2 zone-based firewall closely enough to exercise the API client and
+// the provider resources without a real gateway.
+package udmmock
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultNetworkAppVersion = "9.0.114"  // Network application version reported by default
+	DefaultPassword          = "password" // Password of the admin account created by default
+	DefaultSessionLifetime   = time.Hour  // Lifetime of the sessions issued by default
+	DefaultSite              = "default"  // Name of the site created by default
+	DefaultUsername          = "admin"    // Username of the admin account created by default
+	JWTCookieName            = "TOKEN"    // Name of cookie where the JWT is stored
+)
+
+// Config holds the settings of a Server.  Zero values are replaced with the defaults.
+type Config struct {
+	// Username and Password of the admin account.
+	Username string
+	Password string
+
+	// TOTPSecret is the base32-encoded secret of the admin account's authenticator app.  When set, logins require a
+	// valid TOTP code.
+	TOTPSecret string
+
+	// APIKey is an API key accepted in the X-API-KEY header.  API keys are not accepted if it is empty.
+	APIKey string
+
+	// NetworkAppVersion is the version of the network application reported by the info endpoint.
+	NetworkAppVersion string
+
+	// SessionLifetime is how long the JWTs issued by the login endpoint are valid.
+	SessionLifetime time.Duration
+}
+
+// Request describes a request received by the Server.
+type Request struct {
+	Method string
+	Path   string
+}
+
+// failure describes an injected failure.
+type failure struct {
+	count      int
+	method     string
+	path       string
+	statusCode int
+}
+
+// Server is an in-process stand-in for a UDM.
+type Server struct {
+	config    Config
+	failures  []*failure
+	jwtKey    []byte
+	lock      sync.Mutex
+	nextID    uint64
+	requests  []Request
+	server    *httptest.Server
+	sessions  map[string]session
+	sites     map[string]*site
+	startedAt time.Time
+}
+
+// NewServer creates and starts a new Server with a single "default" site.  Close must be called when done.
+func NewServer(config Config) *Server {
+	if config.Username == "" {
+		config.Username = DefaultUsername
+	}
+	if config.Password == "" {
+		config.Password = DefaultPassword
+	}
+	if config.NetworkAppVersion == "" {
+		config.NetworkAppVersion = DefaultNetworkAppVersion
+	}
+	if config.SessionLifetime == 0 {
+		config.SessionLifetime = DefaultSessionLifetime
+	}
+
+	s := &Server{
+		config:    config,
+		jwtKey:    []byte(fmt.Sprintf("udmmock-%d", time.Now().UnixNano())),
+		sessions:  map[string]session{},
+		sites:     map[string]*site{},
+		startedAt: time.Now(),
+	}
+	s.addSite(DefaultSite, "Default")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/auth/login", s.handleLogin)
+	mux.HandleFunc("GET /proxy/network/v2/api/info", s.authenticated(s.handleGetNetworkAppInfo))
+	mux.HandleFunc("GET /proxy/network/api/s/{site}/rest/{collection}", s.authenticated(s.handleListV1))
+	mux.HandleFunc("POST /proxy/network/api/s/{site}/rest/{collection}", s.authenticated(s.handleCreateV1))
+	mux.HandleFunc("GET /proxy/network/api/s/{site}/rest/{collection}/{id}", s.authenticated(s.handleGetV1))
+	mux.HandleFunc("PUT /proxy/network/api/s/{site}/rest/{collection}/{id}", s.authenticated(s.handleUpdateV1))
+	mux.HandleFunc("DELETE /proxy/network/api/s/{site}/rest/{collection}/{id}", s.authenticated(s.handleDeleteV1))
+	mux.HandleFunc("POST /proxy/network/api/s/{site}/cmd/stamgr", s.authenticated(s.handleStationManager))
+	mux.HandleFunc("POST /proxy/network/api/s/{site}/cmd/sitemgr", s.authenticated(s.handleSiteManager))
+	mux.HandleFunc("GET /proxy/network/api/stat/sites", s.authenticated(s.handleListSites))
+	mux.HandleFunc("GET /proxy/network/v2/api/site/{site}/static-dns", s.authenticated(s.handleListStaticDNSRecords))
+	mux.HandleFunc("POST /proxy/network/v2/api/site/{site}/static-dns", s.authenticated(s.handleCreateStaticDNSRecord))
+	mux.HandleFunc("PUT /proxy/network/v2/api/site/{site}/static-dns/{id}", s.authenticated(s.handleUpdateStaticDNSRecord))
+	mux.HandleFunc("DELETE /proxy/network/v2/api/site/{site}/static-dns/{id}", s.authenticated(s.handleDeleteStaticDNSRecord))
+	mux.HandleFunc("GET /proxy/network/v2/api/site/{site}/firewall/zone", s.authenticated(s.handleListFirewallZones))
+	mux.HandleFunc("POST /proxy/network/v2/api/site/{site}/firewall/zone", s.authenticated(s.handleCreateFirewallZone))
+	mux.HandleFunc("PUT /proxy/network/v2/api/site/{site}/firewall/zone/{id}", s.authenticated(s.handleUpdateFirewallZone))
+	mux.HandleFunc("DELETE /proxy/network/v2/api/site/{site}/firewall/zone/{id}", s.authenticated(s.handleDeleteFirewallZone))
+	mux.HandleFunc("GET /proxy/network/v2/api/site/{site}/firewall-policies", s.authenticated(s.handleListFirewallPolicies))
+	mux.HandleFunc("POST /proxy/network/v2/api/site/{site}/firewall-policies", s.authenticated(s.handleCreateFirewallPolicy))
+	mux.HandleFunc("PUT /proxy/network/v2/api/site/{site}/firewall-policies/{id}", s.authenticated(s.handleUpdateFirewallPolicy))
+	mux.HandleFunc("POST /proxy/network/v2/api/site/{site}/firewall-policies/batch-delete", s.authenticated(s.handleBatchDeleteFirewallPolicies))
+	s.server = httptest.NewTLSServer(s.intercept(mux))
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Certificate returns the server's self-signed certificate.
+func (s *Server) Certificate() *x509.Certificate {
+	return s.server.Certificate()
+}
+
+// Hostname returns the host and port the server listens on, suitable for use as the UDM hostname.
+func (s *Server) Hostname() string {
+	return strings.TrimPrefix(s.server.URL, "https://")
+}
+
+// Requests returns all requests received by the server so far.
+func (s *Server) Requests() []Request {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestCount returns the number of requests received with the given method and path.
+func (s *Server) RequestCount(method, path string) int {
+	count := 0
+	for _, req := range s.Requests() {
+		if req.Method == method && req.Path == path {
+			count++
+		}
+	}
+	return count
+}
+
+// FailRequests makes the next count requests with the given method and path fail with the given status code.
+func (s *Server) FailRequests(method, path string, statusCode, count int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.failures = append(s.failures, &failure{
+		count:      count,
+		method:     method,
+		path:       path,
+		statusCode: statusCode,
+	})
+}
+
+// intercept records every request and applies any injected failures before passing the request to next.
+func (s *Server) intercept(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		s.requests = append(s.requests, Request{
+			Method: r.Method,
+			Path:   r.URL.Path,
+		})
+		for _, f := range s.failures {
+			if f.count > 0 && f.method == r.Method && f.path == r.URL.Path {
+				f.count--
+				s.lock.Unlock()
+				writeJSON(w, f.statusCode, map[string]any{
+					"code":    "INJECTED_FAILURE",
+					"message": http.StatusText(f.statusCode),
+				})
+				return
+			}
+		}
+		s.lock.Unlock()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// newID returns a new unique object ID in the same format as the UDM uses.
+//
+// The caller must hold s.lock.
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("%024x", s.nextID)
+}
+
+// decodeJSON decodes the request body into v, writing an error response and returning false if it is invalid.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]any{
+			"code":    "api.err.InvalidPayload",
+			"message": err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// writeJSON writes v as the JSON response body with the given status code.
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
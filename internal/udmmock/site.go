@@ -0,0 +1,188 @@
+package udmmock
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	CollectionClientDevices    = "user"              // Name of the v1 REST collection holding client devices
+	CollectionDevices          = "device"            // Name of the collection holding adopted devices (eg: APs, switches)
+	CollectionFirewallGroups   = "firewallgroup"     // Name of the v1 REST collection holding firewall groups
+	CollectionFirewallPolicies = "firewall-policies" // Name of the v2 collection holding zone-based firewall policies
+	CollectionFirewallZones    = "firewall/zone"     // Name of the v2 collection holding zone-based firewall zones
+	CollectionFirewallRules    = "firewallrule"      // Name of the v1 REST collection holding legacy firewall rules
+	CollectionNetworks         = "networkconf"       // Name of the v1 REST collection holding networks
+	CollectionPortForwards     = "portforward"       // Name of the v1 REST collection holding port forwards
+	CollectionStaticDNSRecords = "static-dns"        // Name of the v2 collection holding static DNS records
+	CollectionWLANs            = "wlanconf"          // Name of the v1 REST collection holding wireless networks
+)
+
+// SiteInfo describes a site of the Server.
+type SiteInfo struct {
+	Description string
+	ID          string
+	Name        string
+}
+
+// site holds the in-memory state of a single site.
+type site struct {
+	collections map[string][]map[string]any
+	description string
+	id          string
+	name        string
+}
+
+// addSite adds a new site holding only the built-in firewall zones.
+//
+// The caller must hold s.lock unless the server has not been started yet.
+func (s *Server) addSite(name, description string) *site {
+	st := &site{
+		collections: map[string][]map[string]any{},
+		description: description,
+		id:          s.newID(),
+		name:        name,
+	}
+	s.sites[name] = st
+	s.addDefaultFirewallZones(st)
+	return st
+}
+
+// AddSite adds a new site with the given name and description and returns its ID.
+func (s *Server) AddSite(name, description string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.addSite(name, description).id
+}
+
+// Sites returns all of the sites of the server ordered by name.
+func (s *Server) Sites() []SiteInfo {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	sites := make([]SiteInfo, 0, len(s.sites))
+	for _, st := range s.sortedSites() {
+		sites = append(sites, SiteInfo{Description: st.description, ID: st.id, Name: st.name})
+	}
+	return sites
+}
+
+// sortedSites returns all of the sites ordered by name.
+//
+// The caller must hold s.lock.
+func (s *Server) sortedSites() []*site {
+	return slices.SortedFunc(maps.Values(s.sites), func(a, b *site) int {
+		return strings.Compare(a.name, b.name)
+	})
+}
+
+// AddObject adds a copy of object to the named collection of a site, as if it was created outside of the API client
+// (eg: in the UniFi UI), and returns its new ID.  It panics if the site does not exist.
+func (s *Server) AddObject(siteName, collection string, object map[string]any) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st, ok := s.sites[siteName]
+	if !ok {
+		panic("udmmock: unknown site " + siteName)
+	}
+	return st.insert(collection, s.newID(), object)["_id"].(string)
+}
+
+// DeleteObject removes an object from the named collection of a site, as if it was deleted outside of the API client.
+// It returns whether or not the object existed.
+func (s *Server) DeleteObject(siteName, collection, id string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st, ok := s.sites[siteName]
+	if !ok {
+		return false
+	}
+	return st.remove(collection, id)
+}
+
+// Object returns a copy of an object in the named collection of a site.
+func (s *Server) Object(siteName, collection, id string) (map[string]any, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st, ok := s.sites[siteName]
+	if !ok {
+		return nil, false
+	}
+	if object := st.find(collection, id); object != nil {
+		return maps.Clone(object), true
+	}
+	return nil, false
+}
+
+// Objects returns copies of all of the objects in the named collection of a site.
+func (s *Server) Objects(siteName, collection string) []map[string]any {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st, ok := s.sites[siteName]
+	if !ok {
+		return nil
+	}
+	return st.list(collection)
+}
+
+// object returns the site as it is returned by the stat/sites endpoint.
+//
+// The health of the site only reports the number of adopted devices and known clients.
+func (st *site) object() map[string]any {
+	return map[string]any{
+		"_id":            st.id,
+		"attr_hidden_id": st.name,
+		"attr_no_delete": st.name == DefaultSite,
+		"desc":           st.description,
+		"name":           st.name,
+		"role":           "admin",
+		"health": []map[string]any{
+			{
+				"subsystem":   "lan",
+				"status":      "ok",
+				"num_adopted": len(st.collections[CollectionDevices]),
+				"num_user":    len(st.collections[CollectionClientDevices]),
+			},
+		},
+	}
+}
+
+// find returns the object with the given ID in a collection or nil if there is none.
+func (st *site) find(collection, id string) map[string]any {
+	for _, object := range st.collections[collection] {
+		if object["_id"] == id {
+			return object
+		}
+	}
+	return nil
+}
+
+// insert stores a copy of object in a collection under the given ID and returns the stored object.
+func (st *site) insert(collection, id string, object map[string]any) map[string]any {
+	object = maps.Clone(object)
+	object["_id"] = id
+	object["site_id"] = st.id
+	st.collections[collection] = append(st.collections[collection], object)
+	return object
+}
+
+// list returns copies of all of the objects in a collection.
+func (st *site) list(collection string) []map[string]any {
+	objects := make([]map[string]any, 0, len(st.collections[collection]))
+	for _, object := range st.collections[collection] {
+		objects = append(objects, maps.Clone(object))
+	}
+	return objects
+}
+
+// remove deletes the object with the given ID from a collection and returns whether or not it existed.
+func (st *site) remove(collection, id string) bool {
+	objects := st.collections[collection]
+	for i, object := range objects {
+		if object["_id"] == id {
+			st.collections[collection] = append(objects[:i:i], objects[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
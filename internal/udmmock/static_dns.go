@@ -0,0 +1,117 @@
+package udmmock
+
+import (
+	"maps"
+	"net/http"
+)
+
+// writeV2Error writes a failed v2 API response with the given code (eg: api.err.NotFound) and message.
+func writeV2Error(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJSON(w, statusCode, map[string]any{
+		"code":      code,
+		"details":   map[string]any{},
+		"errorCode": statusCode,
+		"message":   message,
+	})
+}
+
+// v2Site returns the site named in the request path, writing an error response and returning nil if it does not
+// exist.
+//
+// The caller must hold s.lock.
+func (s *Server) v2Site(w http.ResponseWriter, r *http.Request) *site {
+	st, ok := s.sites[r.PathValue("site")]
+	if !ok {
+		writeV2Error(w, http.StatusBadRequest, "api.err.NoSiteContext", "site not found")
+		return nil
+	}
+	return st
+}
+
+// validateStaticDNSRecord checks a static DNS record the same way the UDM would, returning the error message to send
+// back if it is invalid.
+func validateStaticDNSRecord(record map[string]any) string {
+	if key, _ := record["key"].(string); key == "" {
+		return "key is required"
+	}
+	if value, _ := record["value"].(string); value == "" {
+		return "value is required"
+	}
+	switch record["record_type"] {
+	case "A", "AAAA", "CNAME", "MX", "NS", "SRV", "TXT":
+		return ""
+	default:
+		return "record_type is invalid"
+	}
+}
+
+// handleListStaticDNSRecords handles GET /proxy/network/v2/api/site/:site/static-dns.
+func (s *Server) handleListStaticDNSRecords(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if st := s.v2Site(w, r); st != nil {
+		writeJSON(w, http.StatusOK, st.list(CollectionStaticDNSRecords))
+	}
+}
+
+// handleCreateStaticDNSRecord handles POST /proxy/network/v2/api/site/:site/static-dns.
+func (s *Server) handleCreateStaticDNSRecord(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	if msg := validateStaticDNSRecord(body); msg != "" {
+		writeV2Error(w, http.StatusBadRequest, "api.err.InvalidPayload", msg)
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if st := s.v2Site(w, r); st != nil {
+		writeJSON(w, http.StatusOK, st.insert(CollectionStaticDNSRecords, s.newID(), body))
+	}
+}
+
+// handleUpdateStaticDNSRecord handles PUT /proxy/network/v2/api/site/:site/static-dns/:id.
+//
+// Unlike the v1 API, the request body replaces the whole object.
+func (s *Server) handleUpdateStaticDNSRecord(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	if msg := validateStaticDNSRecord(body); msg != "" {
+		writeV2Error(w, http.StatusBadRequest, "api.err.InvalidPayload", msg)
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.v2Site(w, r)
+	if st == nil {
+		return
+	}
+	object := st.find(CollectionStaticDNSRecords, r.PathValue("id"))
+	if object == nil {
+		writeV2Error(w, http.StatusNotFound, "api.err.NotFound", "static DNS record not found")
+		return
+	}
+	id, siteID := object["_id"], object["site_id"]
+	clear(object)
+	maps.Copy(object, body)
+	object["_id"], object["site_id"] = id, siteID
+	writeJSON(w, http.StatusOK, object)
+}
+
+// handleDeleteStaticDNSRecord handles DELETE /proxy/network/v2/api/site/:site/static-dns/:id.
+func (s *Server) handleDeleteStaticDNSRecord(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.v2Site(w, r)
+	if st == nil {
+		return
+	}
+	if !st.remove(CollectionStaticDNSRecords, r.PathValue("id")) {
+		writeV2Error(w, http.StatusNotFound, "api.err.NotFound", "static DNS record not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}